@@ -2,7 +2,7 @@ package assertexpectations
 
 import (
 	"fmt"
-	"go/token"
+	"go/ast"
 	"go/types"
 	"slices"
 	"strings"
@@ -10,24 +10,34 @@ import (
 	"github.com/cszczepaniak/gomockcheck/analyzers/internal/typeutils"
 	"github.com/cszczepaniak/gomockcheck/names"
 	"golang.org/x/tools/go/analysis"
-	"golang.org/x/tools/go/analysis/passes/buildssa"
 	"golang.org/x/tools/go/ssa"
 )
 
 func New(typs ...names.QualifiedType) *analysis.Analyzer {
-	r := runner{
+	r := &runner{
 		types: slices.Concat([]names.QualifiedType{{
 			PkgPath: "github.com/stretchr/testify/mock",
 			Name:    "Mock",
 		}}, typs),
 	}
 
-	return &analysis.Analyzer{
-		Name:     "assertexpectations",
-		Doc:      "Ensure that AssertExpectations is called on mock objects before they're used",
-		Run:      r.run,
-		Requires: []*analysis.Analyzer{buildssa.Analyzer},
+	// This analyzer doesn't require buildssa. Analyzers with facts are run on every dependency (and
+	// so is everything they require), and we only want to build SSA for the packages that can use
+	// mocks; see buildSSA.
+	a := &analysis.Analyzer{
+		Name:      "assertexpectations",
+		Doc:       "Ensure that AssertExpectations is called on mock objects before they're used",
+		Run:       r.run,
+		FactTypes: []analysis.Fact{new(constructorFact), new(cleanupHelperFact)},
 	}
+	a.Flags.BoolVar(
+		&r.strict,
+		"strict",
+		false,
+		"require AssertExpectations to be called before anything else in cleanup functions",
+	)
+
+	return a
 }
 
 var debug = false
@@ -47,9 +57,13 @@ func debugf(s string, args ...any) {
 
 type runner struct {
 	types []names.QualifiedType
+
+	// strict is whether to require AssertExpectations to be called first in cleanup functions, so
+	// that calls before it can't panic and skip it.
+	strict bool
 }
 
-func (r runner) isMockObj(obj types.Object) bool {
+func (r *runner) isMockObj(obj types.Object) bool {
 	if obj == nil {
 		return false
 	}
@@ -57,25 +71,43 @@ func (r runner) isMockObj(obj types.Object) bool {
 	return names.IsOneOf(obj, r.types...)
 }
 
-func (r runner) run(pass *analysis.Pass) (any, error) {
-	pssa := pass.ResultOf[buildssa.Analyzer].(*buildssa.SSA)
-	for _, f := range pssa.SrcFuncs {
+func (r *runner) run(pass *analysis.Pass) (any, error) {
+	var facts cleanupFacts
+	facts.helpers = r.exportHelperFacts(pass)
+	facts.constructors = r.exportConstructorFacts(pass, facts.helpers)
+
+	if !r.canUseMocks(pass.Pkg) {
+		return nil, nil
+	}
+
+	for _, f := range buildSSA(pass) {
 		for _, b := range f.Blocks {
 			if b == f.Recover {
 				continue
 			}
 
 			for _, instr := range b.Instrs {
-				alloc, ok := instr.(*ssa.Alloc)
-				if !ok {
-					continue
+				var mock ssa.Value
+				switch instr := instr.(type) {
+				case *ssa.Alloc:
+					mock = instr
+				case *ssa.MakeClosure:
+					if r.strict {
+						r.checkCleanupOrder(pass, instr)
+					}
+				case *ssa.Call, *ssa.Extract:
+					// A mock returned by a constructor that doesn't register AssertExpectations has
+					// to be registered by the caller, just like a newly constructed one.
+					if facts.constructors.unregistered(instr.(ssa.Value)) {
+						mock = instr.(ssa.Value)
+					}
 				}
 
-				if !r.hasEmbeddedMockType(alloc.Type()) {
+				if mock == nil || typeutils.EmbeddedMockType(mock.Type(), r.isMockObj) == nil {
 					continue
 				}
 
-				r.handleReferrers(pass, alloc, f.Recover)
+				r.handleReferrers(pass, facts, mock, f.Recover)
 			}
 		}
 	}
@@ -83,86 +115,184 @@ func (r runner) run(pass *analysis.Pass) (any, error) {
 	return nil, nil
 }
 
-func (r runner) handleReferrers(pass *analysis.Pass, alloc *ssa.Alloc, skipBlock *ssa.BasicBlock) {
-	var pos token.Pos
-	for _, ref := range *alloc.Referrers() {
-		// It's possible that an alloc from one block will refer to the recover block. We don't want
-		// to analyze things inside of the recover block.
-		if ref.Block() == skipBlock {
-			continue
+// canUseMocks returns whether the package imports the package of one of the mock types, directly or
+// indirectly. If it doesn't, it can't construct mocks, so there's nothing to check.
+func (r *runner) canUseMocks(pkg *types.Package) bool {
+	seen := make(map[*types.Package]bool)
+	var visit func(pkg *types.Package) bool
+	visit = func(pkg *types.Package) bool {
+		if seen[pkg] {
+			return false
 		}
-		continuation := r.handleReferrer(alloc, ref)
-		switch c := continuation.(type) {
-		case keepGoing:
-			if pos == 0 {
-				pos = c.pos
+		seen[pkg] = true
+
+		for _, typ := range r.types {
+			if pkg.Path() == typ.PkgPath {
+				return true
 			}
-		case report:
-			if pos == 0 {
-				pos = alloc.Pos()
+		}
+
+		return slices.ContainsFunc(pkg.Imports(), visit)
+	}
+
+	return visit(pkg)
+}
+
+// buildSSA builds the SSA form of the package, returning its source functions (including function
+// literals) in source order, like buildssa does.
+func buildSSA(pass *analysis.Pass) []*ssa.Function {
+	prog := ssa.NewProgram(pass.Fset, 0)
+	for _, p := range pass.Pkg.Imports() {
+		prog.CreatePackage(p, nil, nil, true)
+	}
+
+	ssapkg := prog.CreatePackage(pass.Pkg, pass.Files, pass.TypesInfo, false)
+	ssapkg.Build()
+
+	var funcs []*ssa.Function
+	var addAnons func(f *ssa.Function)
+	addAnons = func(f *ssa.Function) {
+		funcs = append(funcs, f)
+		for _, anon := range f.AnonFuncs {
+			addAnons(anon)
+		}
+	}
+
+	for _, file := range pass.Files {
+		for _, decl := range file.Decls {
+			fd, ok := decl.(*ast.FuncDecl)
+			if !ok {
+				continue
+			}
+
+			fn, ok := pass.TypesInfo.Defs[fd.Name].(*types.Func)
+			if !ok {
+				continue
+			}
+
+			if f := prog.FuncValue(fn); f != nil {
+				addAnons(f)
 			}
-			pass.Reportf(
-				pos,
-				"mocks must have an AssertExpectations registered in a defer or t.Cleanup",
-			)
-			return
-		case succeed:
-			return
 		}
 	}
+
+	return funcs
 }
 
-type continuation interface{ c() }
+// handleReferrers checks that every use of the mock that can execute happens after an
+// AssertExpectations has been registered for it, i.e. that each use is dominated by a registration.
+// Setting up the mock (with On or Test) is also allowed before the registration, as long as the
+// registration is sure to follow, i.e. it post-dominates the setup.
+func (r *runner) handleReferrers(pass *analysis.Pass, facts cleanupFacts, mock ssa.Value, skipBlock *ssa.BasicBlock) {
+	var setups, registrations, uses []ssa.Instruction
+	for _, ref := range *mock.Referrers() {
+		// It's possible that an alloc from one block will refer to the recover block. We don't want
+		// to analyze things inside of the recover block.
+		if ref.Block() == skipBlock {
+			continue
+		}
 
-type report struct{}
+		kind, at := r.handleReferrer(facts, mock, ref)
+		switch kind {
+		case assigned:
+			return
+		case setup:
+			setups = append(setups, at)
+		case registration:
+			registrations = append(registrations, at)
+		case use:
+			uses = append(uses, at)
+		}
+	}
 
-func (report) c() {}
+	// A registration doesn't have to happen at the same place on every path (e.g. it could be in
+	// both branches of an if), as long as one happens before any use on every path. Setups can
+	// also come before the registration.
+	var offending []ssa.Instruction
+	for _, u := range uses {
+		if !coveredBefore(registrations, u) {
+			offending = append(offending, u)
+		}
+	}
+	for _, s := range setups {
+		if !coveredBefore(registrations, s) && !coveredAfter(registrations, s) {
+			offending = append(offending, s)
+		}
+	}
 
-type succeed struct{}
+	if len(offending) == 0 {
+		return
+	}
 
-func (succeed) c() {}
+	pos := firstUse(offending)
+	if !pos.IsValid() {
+		pos = mock.Pos()
+	}
 
-type keepGoing struct {
-	pos token.Pos
+	pass.Reportf(pos, "mocks must have an AssertExpectations registered in a defer or t.Cleanup")
 }
 
-func (keepGoing) c() {}
+// refKind describes how an instruction refers to a mock.
+type refKind int
+
+const (
+	// ignored means the instruction doesn't use the mock, e.g. it initializes it.
+	ignored refKind = iota
+	// setup means the instruction sets up the mock with On or Test, which is allowed before
+	// AssertExpectations is registered.
+	setup
+	// registration means the instruction registers AssertExpectations in a defer or t.Cleanup.
+	registration
+	// use means the instruction uses the mock.
+	use
+	// assigned means the variable holding the mock is assigned one from somewhere else, e.g. a
+	// function call. Mocks returned by constructors that don't register AssertExpectations are
+	// analyzed at the call, so we can assume that anything else has set up AssertExpectations
+	// correctly.
+	assigned
+)
 
-func (r runner) handleReferrer(alloc *ssa.Alloc, instr ssa.Instruction) continuation {
+// handleReferrer classifies an instruction referring to the mock. It also returns the instruction
+// where that happens; for a registration in a closure, that's where the closure is deferred or
+// passed to t.Cleanup rather than where it's created.
+func (r *runner) handleReferrer(facts cleanupFacts, mock ssa.Value, instr ssa.Instruction) (refKind, ssa.Instruction) {
 	switch ref := instr.(type) {
 	case *ssa.Store:
-		if ref.Addr != alloc {
-			return keepGoing{}
+		if ref.Addr != mock {
+			// Storing the mock in a slice that's passed to a cleanup helper registers it.
+			if reg := facts.helpers.registration(mock, ref); reg != nil {
+				return registration, reg
+			}
+			return ignored, ref
 		}
 
-		_, ok := ref.Val.(*ssa.Alloc)
-		if ok {
+		if _, ok := ref.Val.(*ssa.Alloc); ok {
 			// If the RHS of the store operation is an allocation, that means it's a struct literal
-			// and we should analyze it. Let's include the position of the value because there are
-			// some cases that the position of the Addr here points to an unintuitive location.
-			return keepGoing{pos: ref.Val.Pos()}
-		} else {
-			// If it's not an allocation it could be something like a function call. If we're
-			// storing the result of a function call, we'll analyze that function elsewhere so we
-			// can assume it has set up AssertExpectations correctly.
-			return succeed{}
+			// and we should analyze it.
+			return ignored, ref
+		}
+		if facts.constructors.unregistered(ref.Val) {
+			// The mock comes from a constructor that doesn't register AssertExpectations, so it
+			// has to be registered here.
+			return ignored, ref
 		}
+		return assigned, ref
 	case *ssa.MakeClosure:
 		// This is the case that we're referring to the mock in a closure. We'll check to see if
 		// this is a closure passed into a t.Cleanup or a closure in a defer, and if that closure
 		// calls AssertExpectations.
-		isCleanup := false
+		var cleanup ssa.Instruction
 		for _, ref := range *ref.Referrers() {
-			isCleanup = isTCleanupOrDefer(ref)
-			if isCleanup {
+			if isTCleanupOrDefer(ref) {
+				cleanup = ref
 				break
 			}
 		}
 
-		// If it's not a cleanup function, we should report because we're using the mock before setting up
-		// the defer or cleanup.
-		if !isCleanup {
-			return report{}
+		// If it's not a cleanup function, we're using the mock in a closure that could be called
+		// at any time.
+		if cleanup == nil {
+			return use, ref
 		}
 
 		// We're closing over the mock, so it'll be one of the free variables in the closure. We'll find it
@@ -170,7 +300,7 @@ func (r runner) handleReferrer(alloc *ssa.Alloc, instr ssa.Instruction) continua
 		var freeVar *ssa.FreeVar
 		closure := ref.Fn.(*ssa.Function)
 		for i, b := range ref.Bindings {
-			if b == alloc {
+			if b == mock {
 				freeVar = closure.FreeVars[i]
 				break
 			}
@@ -182,38 +312,87 @@ func (r runner) handleReferrer(alloc *ssa.Alloc, instr ssa.Instruction) continua
 				continue
 			}
 
-			// Currently we only enforce that there's an AssertExpectations call somewhere in the cleanup
-			// function, but we could maybe also additionally force it to be the first call.
+			// We only enforce that there's an AssertExpectations call somewhere in the cleanup
+			// function. In strict mode, checkCleanupOrder also enforces that it's called first.
 			c := resultantCall(val)
 			if c != nil && r.isMockFunc(c.Call, "AssertExpectations") {
-				return succeed{}
+				return registration, cleanup
+			}
+
+			if assertForObjectsCall(val) != nil {
+				return registration, cleanup
 			}
 		}
 
-		return report{}
+		return use, cleanup
 
 	case ssa.Value:
+		// Initializing the fields of a struct literal (e.g. an embedded *mock.Mock) isn't a use of
+		// the mock.
+		if isFieldInit(ref) {
+			return ignored, instr
+		}
+
+		// Functions with defers store their results in variables and load them when they return.
+		if facts.constructors.handedOff(ref) {
+			return ignored, instr
+		}
+
+		// Passing the mock to a cleanup helper registers AssertExpectations for it.
+		if reg := facts.helpers.registration(mock, instr); reg != nil {
+			return registration, reg
+		}
+
 		// We allow calling mock.Test(t) before setting up AssertExpectations; this is fine to do
 		// and they can be done in either order.
 		c := resultantCall(ref)
 		if c != nil && r.isMockFunc(c.Call, "Test", "On") {
-			return keepGoing{}
+			return setup, c
 		}
 
 		// Check to see if this value is referred to in a defer statement, which we allow. The defer
 		// must be in a top-level test function.
 		deferredCall, ok := deferredCall(ref)
-		if !ok || !r.isMockFunc(deferredCall, "AssertExpectations") {
-			return report{}
+		if ok && r.isMockFunc(deferredCall, "AssertExpectations") {
+			return registration, instr
+		}
+
+		// The mock can also be passed to a deferred mock.AssertExpectationsForObjects.
+		if def, ok := assertForObjectsCall(ref).(*ssa.Defer); ok {
+			return registration, def
 		}
 
-		return succeed{}
+		return use, instr
+
+	case *ssa.Return:
+		// Returning the mock from a constructor that doesn't register AssertExpectations for it
+		// leaves that to the caller, which is where it's reported.
+		if facts.constructors.handsOff(ref, mock) {
+			return ignored, ref
+		}
+		return use, ref
 
 	default:
-		return report{}
+		return use, ref
 	}
 }
 
+// isFieldInit returns whether the value is the address of a field that's only ever stored to.
+func isFieldInit(val ssa.Value) bool {
+	addr, ok := val.(*ssa.FieldAddr)
+	if !ok {
+		return false
+	}
+
+	for _, ref := range *addr.Referrers() {
+		if store, ok := ref.(*ssa.Store); !ok || store.Addr != addr {
+			return false
+		}
+	}
+
+	return true
+}
+
 func deferredCall(val ssa.Value) (ssa.CallCommon, bool) {
 	for _, ref := range *val.Referrers() {
 		switch ref := ref.(type) {
@@ -250,35 +429,6 @@ func resultantCall(val ssa.Value) *ssa.Call {
 	return nil
 }
 
-func (r runner) hasEmbeddedMockType(typ types.Type) bool {
-	switch typ := typ.(type) {
-	case *types.Pointer:
-		return r.hasEmbeddedMockType(typ.Elem())
-	case *types.Named:
-		return r.hasEmbeddedMockType(typ.Underlying())
-	case *types.Struct:
-		for i := range typ.NumFields() {
-			f := typ.Field(i)
-			if !f.Embedded() {
-				continue
-			}
-
-			named, ok := f.Type().(*types.Named)
-			if !ok {
-				continue
-			}
-
-			if r.isMockObj(named.Obj()) {
-				return true
-			}
-		}
-
-		return false
-	default:
-		return false
-	}
-}
-
 func isTCleanupOrDefer(val ssa.Instruction) bool {
 	switch val.(type) {
 	case *ssa.Defer:
@@ -297,11 +447,11 @@ func isTCleanupOrDefer(val ssa.Instruction) bool {
 		sig = call.Call.Method.Signature()
 		name = call.Call.Method.Name()
 	} else {
-		sig = call.Call.Value.Type().(*types.Signature)
+		sig, _ = call.Call.Value.Type().Underlying().(*types.Signature)
 		name = call.Call.Value.Name()
 	}
 
-	if name != "Cleanup" || sig.Recv() == nil || sig.Params().Len() != 1 {
+	if name != "Cleanup" || sig == nil || sig.Recv() == nil || sig.Params().Len() != 1 {
 		return false
 	}
 
@@ -313,17 +463,58 @@ func isTCleanupOrDefer(val ssa.Instruction) bool {
 	return paramTyp.Params().Len() == 0 && paramTyp.Results().Len() == 0
 }
 
-func (r runner) isMockFunc(call ssa.CallCommon, oneOf ...string) bool {
+func (r *runner) isMockFunc(call ssa.CallCommon, oneOf ...string) bool {
 	obj := typeutils.GetObjForPtrToNamedType(call.Args[0].Type())
 	if !r.isMockObj(obj) {
 		return false
 	}
 
-	for _, name := range oneOf {
-		if call.Value.Name() == name {
-			return true
+	return slices.Contains(oneOf, calleeName(call))
+}
+
+// assertForObjectsCall returns the call to mock.AssertExpectationsForObjects that the value is
+// passed to, or nil if there isn't one. The mocks are passed to it as variadic arguments, so they're
+// converted to interfaces and stored in a slice first.
+func assertForObjectsCall(val ssa.Value) ssa.CallInstruction {
+	for _, ref := range *val.Referrers() {
+		switch ref := ref.(type) {
+		case *ssa.MakeInterface, *ssa.ChangeInterface, *ssa.UnOp:
+			if call := assertForObjectsCall(ref.(ssa.Value)); call != nil {
+				return call
+			}
+		case *ssa.Store:
+			if ref.Val != val {
+				continue
+			}
+
+			call, _ := sliceArg(ref)
+			if call == nil || call.Common().StaticCallee() == nil {
+				continue
+			}
+
+			fn, _ := call.Common().StaticCallee().Object().(*types.Func)
+			if isAssertExpectationsForObjects(fn) {
+				return call
+			}
 		}
 	}
 
-	return false
+	return nil
+}
+
+// isAssertExpectationsForObjects returns whether the function is testify's
+// mock.AssertExpectationsForObjects, which calls AssertExpectations on each of the mocks passed to
+// it.
+func isAssertExpectationsForObjects(fn *types.Func) bool {
+	return fn != nil && names.IsTestifySymbol(fn, "AssertExpectationsForObjects")
+}
+
+// calleeName returns the name of the function that's called. For instantiations of generic
+// functions (including methods of generic mock types), that's the name of the generic function,
+// without type arguments.
+func calleeName(call ssa.CallCommon) string {
+	if fn, ok := call.Value.(*ssa.Function); ok && fn.Origin() != nil {
+		return fn.Origin().Name()
+	}
+	return call.Value.Name()
 }
@@ -18,7 +18,23 @@ func TestAssertExpectations_CustomNames(t *testing.T) {
 		New(names.QualifiedType{
 			PkgPath: "example.com/customtype",
 			Name:    "MyMockType",
+		}, names.QualifiedType{
+			PkgPath: "example.com/customtype",
+			Name:    "GenericMockType",
 		}),
 		"./customtype",
 	)
 }
+
+func TestAssertExpectations_Facts(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), New(), "./constructors", "./testutil")
+}
+
+func TestAssertExpectations_Strict(t *testing.T) {
+	a := New()
+	if err := a.Flags.Set("strict", "true"); err != nil {
+		t.Fatal(err)
+	}
+
+	analysistest.RunWithSuggestedFixes(t, analysistest.TestData(), a, "./strict")
+}
@@ -0,0 +1,335 @@
+package assertexpectations
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+	"slices"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ssa"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+// cleanupHelperFact is a fact about a function that registers AssertExpectations with t.Cleanup for
+// mocks passed to some of its parameters, e.g.
+//
+//	func expectAll(t testing.TB, ms ...interface{ AssertExpectations(mock.TestingT) bool }) {
+//		t.Cleanup(func() {
+//			for _, m := range ms {
+//				m.AssertExpectations(t)
+//			}
+//		})
+//	}
+//
+// Passing a mock to one of those parameters counts as registering AssertExpectations for it.
+type cleanupHelperFact struct {
+	// Params holds the indices of the parameters that AssertExpectations is registered for.
+	Params []int
+}
+
+func (*cleanupHelperFact) AFact() {}
+
+func (f *cleanupHelperFact) String() string {
+	return fmt.Sprintf("cleanupHelper%v", f.Params)
+}
+
+// helperFacts holds the facts about the cleanup helpers in the package being analyzed and the
+// packages it imports.
+type helperFacts map[*types.Func]*cleanupHelperFact
+
+// cleanupFacts holds everything we know about the functions that register AssertExpectations.
+type cleanupFacts struct {
+	constructors constructorFacts
+	helpers      helperFacts
+}
+
+// registersArg returns whether the call passes the value to a parameter of a cleanup helper that
+// registers AssertExpectations for it.
+func (facts helperFacts) registersArg(call *ssa.CallCommon, val ssa.Value) bool {
+	callee := call.StaticCallee()
+	if callee == nil {
+		return false
+	}
+	if origin := callee.Origin(); origin != nil {
+		callee = origin
+	}
+
+	fn, ok := callee.Object().(*types.Func)
+	if !ok {
+		return false
+	}
+
+	fact := facts[fn]
+	if fact == nil {
+		return false
+	}
+
+	args := call.Args
+	if fn.Signature().Recv() != nil {
+		// The receiver of a static method call is its first argument.
+		args = args[1:]
+	}
+
+	for _, i := range fact.Params {
+		if i < len(args) && args[i] == val {
+			return true
+		}
+	}
+	return false
+}
+
+// registration returns the call that registers AssertExpectations for the value by passing it to
+// a cleanup helper, or nil if the instruction doesn't lead to one. The value can be passed directly,
+// after it's converted (e.g. to an interface), or in a slice, like the variadic arguments of the
+// helper.
+func (facts helperFacts) registration(val ssa.Value, instr ssa.Instruction) ssa.Instruction {
+	switch instr := instr.(type) {
+	case *ssa.Call:
+		if facts.registersArg(instr.Common(), val) {
+			return instr
+		}
+
+	case *ssa.MakeInterface, *ssa.ChangeType, *ssa.ChangeInterface, *ssa.UnOp:
+		converted := instr.(ssa.Value)
+		for _, ref := range *converted.Referrers() {
+			if reg := facts.registration(converted, ref); reg != nil {
+				return reg
+			}
+		}
+
+	case *ssa.Store:
+		if instr.Val != val {
+			return nil
+		}
+
+		call, slice := sliceArg(instr)
+		if call, ok := call.(*ssa.Call); ok && facts.registersArg(call.Common(), slice) {
+			return call
+		}
+	}
+
+	return nil
+}
+
+// sliceArg returns the call that gets the slice the store writes an element of, e.g. the slice
+// that's built for the variadic arguments of a function, along with the slice. It returns nil if
+// there isn't one.
+func sliceArg(store *ssa.Store) (ssa.CallInstruction, ssa.Value) {
+	elem, ok := store.Addr.(*ssa.IndexAddr)
+	if !ok {
+		return nil, nil
+	}
+
+	for _, ref := range *elem.X.Referrers() {
+		slice, ok := ref.(*ssa.Slice)
+		if !ok {
+			continue
+		}
+
+		for _, ref := range *slice.Referrers() {
+			if call, ok := ref.(ssa.CallInstruction); ok && slices.Contains(call.Common().Args, ssa.Value(slice)) {
+				return call, slice
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+// exportHelperFacts exports facts for the functions in the package that register AssertExpectations
+// for their parameters. Only registrations that are sure to happen count, so they have to be made
+// by statements at the top level of the function's body.
+func (r *runner) exportHelperFacts(pass *analysis.Pass) helperFacts {
+	decls := make(map[*types.Func]*ast.FuncDecl)
+	for _, file := range pass.Files {
+		for _, decl := range file.Decls {
+			fd, ok := decl.(*ast.FuncDecl)
+			if !ok || fd.Body == nil {
+				continue
+			}
+
+			fn, ok := pass.TypesInfo.Defs[fd.Name].(*types.Func)
+			if ok && fn.Signature().Params().Len() > 0 {
+				decls[fn] = fd
+			}
+		}
+	}
+
+	facts := make(helperFacts)
+	for _, f := range pass.AllObjectFacts() {
+		fn, ok := f.Object.(*types.Func)
+		if fact, isHelper := f.Fact.(*cleanupHelperFact); ok && isHelper {
+			facts[fn] = fact
+		}
+	}
+
+	// Helpers can pass their parameters on to other helpers, so keep going until we've learned all
+	// we can.
+	for changed := true; changed; {
+		changed = false
+		for fn, decl := range decls {
+			params := fn.Signature().Params()
+
+			var registered []int
+			for _, stmt := range decl.Body.List {
+				for _, obj := range r.registeredObjs(pass.TypesInfo, facts, stmt) {
+					for i := range params.Len() {
+						if params.At(i) == obj && !slices.Contains(registered, i) {
+							registered = append(registered, i)
+						}
+					}
+				}
+			}
+
+			if len(registered) == 0 {
+				continue
+			}
+			slices.Sort(registered)
+
+			if old, ok := facts[fn]; ok && slices.Equal(old.Params, registered) {
+				continue
+			}
+			facts[fn] = &cleanupHelperFact{Params: registered}
+			changed = true
+		}
+	}
+
+	for fn := range decls {
+		if fact, ok := facts[fn]; ok {
+			pass.ExportObjectFact(fn, fact)
+		}
+	}
+
+	return facts
+}
+
+// registeredObjs returns the variables whose mocks have AssertExpectations registered by the
+// statement. That's either a call to t.Cleanup with a closure that calls AssertExpectations on
+// them, or a call to a cleanup helper that they're passed to.
+func (r *runner) registeredObjs(info *types.Info, helpers helperFacts, stmt ast.Stmt) []types.Object {
+	exprStmt, ok := stmt.(*ast.ExprStmt)
+	if !ok {
+		return nil
+	}
+
+	call, ok := exprStmt.X.(*ast.CallExpr)
+	if !ok {
+		return nil
+	}
+
+	if lit := cleanupClosure(info, call); lit != nil {
+		return assertedObjs(info, lit)
+	}
+
+	fn := typeutil.StaticCallee(info, call)
+	if fn == nil {
+		return nil
+	}
+
+	fact := helpers[fn.Origin()]
+	if fact == nil {
+		return nil
+	}
+
+	sig := fn.Signature()
+	var objs []types.Object
+	for _, i := range fact.Params {
+		args := call.Args[i:min(i+1, len(call.Args))]
+		if sig.Variadic() && i == sig.Params().Len()-1 && !call.Ellipsis.IsValid() {
+			// The mocks passed as variadic arguments.
+			args = call.Args[i:]
+		}
+
+		for _, arg := range args {
+			if obj := rootIdentObj(info, arg); obj != nil {
+				objs = append(objs, obj)
+			}
+		}
+	}
+
+	return objs
+}
+
+// cleanupClosure returns the closure that's passed to t.Cleanup in the call, or nil if it isn't a
+// call to t.Cleanup with a function literal.
+func cleanupClosure(info *types.Info, call *ast.CallExpr) *ast.FuncLit {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || sel.Sel.Name != "Cleanup" || len(call.Args) != 1 {
+		return nil
+	}
+
+	if s := info.Selections[sel]; s == nil || s.Kind() != types.MethodVal {
+		return nil
+	}
+
+	lit, _ := call.Args[0].(*ast.FuncLit)
+	return lit
+}
+
+// assertedObjs returns the variables that AssertExpectations is called on in the closure, or that
+// are passed to mock.AssertExpectationsForObjects. Calls on the elements of a slice, e.g. in a loop
+// over it, count as calls on the slice.
+func assertedObjs(info *types.Info, lit *ast.FuncLit) []types.Object {
+	elems := make(map[types.Object]types.Object)
+
+	var objs []types.Object
+	ast.Inspect(lit.Body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.RangeStmt:
+			if id, ok := n.Value.(*ast.Ident); ok {
+				if slice := rootIdentObj(info, n.X); slice != nil {
+					elems[info.Defs[id]] = slice
+				}
+			}
+
+		case *ast.CallExpr:
+			if isAssertExpectationsForObjects(typeutil.StaticCallee(info, n)) {
+				for _, arg := range n.Args[1:] {
+					if obj := rootIdentObj(info, arg); obj != nil {
+						objs = append(objs, obj)
+					}
+				}
+				return true
+			}
+
+			sel, ok := n.Fun.(*ast.SelectorExpr)
+			if !ok || sel.Sel.Name != "AssertExpectations" {
+				return true
+			}
+
+			fn, ok := typeutil.Callee(info, n).(*types.Func)
+			if !ok || fn.Signature().Recv() == nil {
+				return true
+			}
+
+			obj := rootIdentObj(info, sel.X)
+			if slice, ok := elems[obj]; ok {
+				obj = slice
+			}
+			if obj != nil {
+				objs = append(objs, obj)
+			}
+		}
+		return true
+	})
+
+	return objs
+}
+
+// rootIdentObj returns the object of the identifier that a chain of selections starts at, e.g. m in
+// m.Mock or ms in ms[0].
+func rootIdentObj(info *types.Info, expr ast.Expr) types.Object {
+	for {
+		switch e := ast.Unparen(expr).(type) {
+		case *ast.Ident:
+			return info.Uses[e]
+		case *ast.SelectorExpr:
+			expr = e.X
+		case *ast.IndexExpr:
+			expr = e.X
+		default:
+			return nil
+		}
+	}
+}
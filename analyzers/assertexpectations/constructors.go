@@ -0,0 +1,365 @@
+package assertexpectations
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"slices"
+
+	"github.com/cszczepaniak/gomockcheck/analyzers/internal/typeutils"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/astutil"
+	"golang.org/x/tools/go/ssa"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+// constructorFact is a fact about a function that returns mocks, saying which of them it doesn't
+// register AssertExpectations for with t.Cleanup. A deferred AssertExpectations doesn't count,
+// because it runs when the constructor returns, before the mock is used. The caller has to register
+// AssertExpectations for those mocks itself.
+type constructorFact struct {
+	// Unregistered holds the indices of the results that AssertExpectations isn't registered for.
+	Unregistered []int
+}
+
+func (*constructorFact) AFact() {}
+
+func (f *constructorFact) String() string {
+	if len(f.Unregistered) == 0 {
+		return "registersCleanup"
+	}
+	return fmt.Sprintf("noCleanup%v", f.Unregistered)
+}
+
+// constructorFacts holds the facts about the constructors in the package being analyzed and the
+// packages it imports.
+type constructorFacts map[*types.Func]*constructorFact
+
+// unregistered returns whether the value is a mock returned by a constructor that doesn't register
+// AssertExpectations for it.
+func (facts constructorFacts) unregistered(val ssa.Value) bool {
+	call, idx := val, 0
+	if extract, ok := val.(*ssa.Extract); ok {
+		call, idx = extract.Tuple, extract.Index
+	}
+
+	c, ok := call.(*ssa.Call)
+	if !ok {
+		return false
+	}
+
+	callee := c.Call.StaticCallee()
+	if callee == nil {
+		return false
+	}
+	if origin := callee.Origin(); origin != nil {
+		callee = origin
+	}
+
+	fn, ok := callee.Object().(*types.Func)
+	if !ok {
+		return false
+	}
+
+	fact := facts[fn]
+	return fact != nil && slices.Contains(fact.Unregistered, idx)
+}
+
+// handsOff returns whether the return statement returns the mock from a constructor that doesn't
+// register AssertExpectations for it, leaving it to the caller.
+func (facts constructorFacts) handsOff(ret *ssa.Return, mock ssa.Value) bool {
+	fn, ok := ret.Parent().Object().(*types.Func)
+	if !ok {
+		return false
+	}
+
+	fact := facts[fn]
+	if fact == nil {
+		return false
+	}
+
+	for i, res := range ret.Results {
+		if res == mock && slices.Contains(fact.Unregistered, i) {
+			return true
+		}
+	}
+	return false
+}
+
+// handedOff returns whether the value is a load of a result variable that's only returned from a
+// constructor that doesn't register AssertExpectations for it.
+func (facts constructorFacts) handedOff(val ssa.Value) bool {
+	load, ok := val.(*ssa.UnOp)
+	if !ok || load.Op != token.MUL || len(*load.Referrers()) == 0 {
+		return false
+	}
+
+	for _, ref := range *load.Referrers() {
+		ret, ok := ref.(*ssa.Return)
+		if !ok || !facts.handsOff(ret, load) {
+			return false
+		}
+	}
+	return true
+}
+
+// constructor is a function declaration that returns mocks.
+type constructor struct {
+	file *ast.File
+	decl *ast.FuncDecl
+	fn   *types.Func
+}
+
+// exportConstructorFacts exports facts for the functions in the package that return mocks. A
+// function gets a fact only if it can be told where at least one of the mocks it returns comes
+// from: a composite literal, or another constructor. Others, like getters of mocks stored in
+// structs, are assumed to return mocks that are registered elsewhere.
+func (r *runner) exportConstructorFacts(pass *analysis.Pass, helpers helperFacts) constructorFacts {
+	var constructors []constructor
+	for _, file := range pass.Files {
+		for _, decl := range file.Decls {
+			fd, ok := decl.(*ast.FuncDecl)
+			if !ok || fd.Body == nil {
+				continue
+			}
+
+			fn, ok := pass.TypesInfo.Defs[fd.Name].(*types.Func)
+			if ok && r.returnsMock(fn) {
+				constructors = append(constructors, constructor{file: file, decl: fd, fn: fn})
+			}
+		}
+	}
+
+	facts := cleanupFacts{
+		constructors: make(constructorFacts),
+		helpers:      helpers,
+	}
+	for _, f := range pass.AllObjectFacts() {
+		fn, ok := f.Object.(*types.Func)
+		if fact, isConstructor := f.Fact.(*constructorFact); ok && isConstructor {
+			facts.constructors[fn] = fact
+		}
+	}
+
+	// Constructors can return the mocks of other constructors, so keep going until we've learned
+	// all we can.
+	for changed := true; changed; {
+		changed = false
+		for _, c := range constructors {
+			fact := r.constructorFact(pass.TypesInfo, facts, c)
+			if fact == nil {
+				continue
+			}
+
+			if old, ok := facts.constructors[c.fn]; ok && slices.Equal(old.Unregistered, fact.Unregistered) {
+				continue
+			}
+			facts.constructors[c.fn] = fact
+			changed = true
+		}
+	}
+
+	for _, c := range constructors {
+		if fact, ok := facts.constructors[c.fn]; ok {
+			pass.ExportObjectFact(c.fn, fact)
+		}
+	}
+
+	return facts.constructors
+}
+
+func (r *runner) returnsMock(fn *types.Func) bool {
+	results := fn.Signature().Results()
+	for i := range results.Len() {
+		if typeutils.EmbeddedMockType(results.At(i).Type(), r.isMockObj) != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// constructorFact returns the fact about the constructor, or nil if we don't know where any of the
+// mocks it returns come from.
+func (r *runner) constructorFact(info *types.Info, facts cleanupFacts, c constructor) *constructorFact {
+	results := c.fn.Signature().Results()
+
+	known := false
+	unregistered := make([]bool, results.Len())
+	ast.Inspect(c.decl.Body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			// Returns in closures don't return from the constructor.
+			return false
+		case *ast.ReturnStmt:
+			for i := range results.Len() {
+				if typeutils.EmbeddedMockType(results.At(i).Type(), r.isMockObj) == nil {
+					continue
+				}
+
+				registered, ok := r.returnRegistered(info, facts, c, n, i)
+				if !ok {
+					continue
+				}
+
+				known = true
+				if !registered {
+					unregistered[i] = true
+				}
+			}
+		}
+		return true
+	})
+
+	if !known {
+		return nil
+	}
+
+	fact := &constructorFact{}
+	for i, u := range unregistered {
+		if u {
+			fact.Unregistered = append(fact.Unregistered, i)
+		}
+	}
+	return fact
+}
+
+// returnRegistered returns whether AssertExpectations is registered with t.Cleanup for the i-th
+// result of the return statement. It returns false for ok if we can't tell where the mock comes
+// from, e.g. for a bare return.
+func (r *runner) returnRegistered(
+	info *types.Info,
+	facts cleanupFacts,
+	c constructor,
+	ret *ast.ReturnStmt,
+	i int,
+) (registered, ok bool) {
+	switch len(ret.Results) {
+	case c.fn.Signature().Results().Len():
+		return r.exprRegistered(info, facts, c, ret, ret.Results[i], 0)
+	case 1:
+		// Returning the results of a call to another function, e.g. return newMocks(t).
+		return r.exprRegistered(info, facts, c, ret, ret.Results[0], i)
+	default:
+		return false, false
+	}
+}
+
+// exprRegistered returns whether AssertExpectations is registered with t.Cleanup for the mock
+// that's the value of the expression (or the idx-th value, for a call returning several) by the
+// time the return statement is reached.
+func (r *runner) exprRegistered(
+	info *types.Info,
+	facts cleanupFacts,
+	c constructor,
+	ret *ast.ReturnStmt,
+	expr ast.Expr,
+	idx int,
+) (registered, ok bool) {
+	switch expr := ast.Unparen(expr).(type) {
+	case *ast.UnaryExpr:
+		_, isLit := ast.Unparen(expr.X).(*ast.CompositeLit)
+		return false, isLit
+
+	case *ast.CallExpr:
+		fn := typeutil.StaticCallee(info, expr)
+		if fn == nil {
+			return false, false
+		}
+
+		fact, ok := facts.constructors[fn.Origin()]
+		if !ok {
+			return false, false
+		}
+		return !slices.Contains(fact.Unregistered, idx), true
+
+	case *ast.Ident:
+		v, isVar := info.Uses[expr].(*types.Var)
+		if !isVar || v.Pos() < c.decl.Body.Pos() || v.Pos() >= c.decl.Body.End() {
+			// Parameters and variables declared elsewhere aren't constructed here.
+			return false, false
+		}
+
+		if r.cleanupRegistered(info, facts, c, ret, v) {
+			return true, true
+		}
+
+		// Find out where the mock in the variable comes from.
+		ast.Inspect(c.decl.Body, func(n ast.Node) bool {
+			assign, isAssign := n.(*ast.AssignStmt)
+			if !isAssign {
+				return true
+			}
+
+			for j, lhs := range assign.Lhs {
+				id, isIdent := lhs.(*ast.Ident)
+				if !isIdent || (info.Defs[id] != v && info.Uses[id] != v) {
+					continue
+				}
+
+				rhs, rhsIdx := assign.Rhs[0], j
+				if len(assign.Rhs) == len(assign.Lhs) {
+					rhs, rhsIdx = assign.Rhs[j], 0
+				}
+				if _, isIdent := ast.Unparen(rhs).(*ast.Ident); isIdent {
+					continue
+				}
+
+				rhsRegistered, rhsOK := r.exprRegistered(info, facts, c, ret, rhs, rhsIdx)
+				registered = registered || rhsRegistered
+				ok = ok || rhsOK
+			}
+			return true
+		})
+		return registered, ok
+
+	default:
+		return false, false
+	}
+}
+
+// cleanupRegistered returns whether AssertExpectations is registered for the mock in the variable
+// with t.Cleanup in a statement that's sure to run before the return statement, i.e. one that comes
+// before it in the same block or in a block that encloses it.
+func (r *runner) cleanupRegistered(
+	info *types.Info,
+	facts cleanupFacts,
+	c constructor,
+	ret *ast.ReturnStmt,
+	v *types.Var,
+) bool {
+	path, _ := astutil.PathEnclosingInterval(c.file, ret.Pos(), ret.End())
+	for i, n := range path {
+		var stmts []ast.Stmt
+		switch n := n.(type) {
+		case *ast.BlockStmt:
+			stmts = n.List
+		case *ast.CaseClause:
+			stmts = n.Body
+		case *ast.CommClause:
+			stmts = n.Body
+		default:
+			continue
+		}
+
+		if i == 0 {
+			continue
+		}
+		inner := path[i-1]
+		for _, stmt := range stmts {
+			if stmt.Pos() >= inner.Pos() {
+				break
+			}
+
+			if slices.Contains(r.registeredObjs(info, facts.helpers, stmt), types.Object(v)) {
+				return true
+			}
+		}
+
+		if n == c.decl.Body {
+			break
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,147 @@
+package assertexpectations
+
+import (
+	"go/token"
+	"slices"
+
+	"golang.org/x/tools/go/ssa"
+)
+
+// dominates returns whether every path through the function to b goes through a first.
+func dominates(a, b ssa.Instruction) bool {
+	if a.Block() == b.Block() {
+		return instrIndex(a) < instrIndex(b)
+	}
+	return a.Block().Dominates(b.Block())
+}
+
+// coveredBefore returns whether every path through the function to instr goes through at least one
+// of the instructions in set.
+func coveredBefore(set []ssa.Instruction, instr ssa.Instruction) bool {
+	if slices.ContainsFunc(set, func(other ssa.Instruction) bool {
+		return other.Block() == instr.Block() && instrIndex(other) < instrIndex(instr)
+	}) {
+		return true
+	}
+
+	// Look for a path from the entry to the block that avoids the blocks holding the set. The ones
+	// in the block itself come after instr, so they don't count.
+	f := instr.Parent()
+	if f.Blocks[0] == instr.Block() {
+		return false
+	}
+
+	blocked := blocksOf(f, set)
+	blocked[instr.Block().Index] = false
+	if blocked[0] {
+		return true
+	}
+
+	return !reaches(f.Blocks[0], blocked, func(b *ssa.BasicBlock) bool { return b == instr.Block() })
+}
+
+// coveredAfter returns whether every path from instr to the end of the function goes through at
+// least one of the instructions in set. Paths that end in a panic are ignored; nothing after the
+// panic runs, so it doesn't matter what does or doesn't happen on them.
+func coveredAfter(set []ssa.Instruction, instr ssa.Instruction) bool {
+	if slices.ContainsFunc(set, func(other ssa.Instruction) bool {
+		return other.Block() == instr.Block() && instrIndex(other) > instrIndex(instr)
+	}) {
+		return true
+	}
+
+	isExit := func(b *ssa.BasicBlock) bool { return len(b.Succs) == 0 && !endsInPanic(b) }
+	if isExit(instr.Block()) {
+		return false
+	}
+
+	// Look for a path from the block to an exit that avoids the blocks holding the set.
+	return !reaches(instr.Block(), blocksOf(instr.Parent(), set), isExit)
+}
+
+// blocksOf returns, for each block of the function (by index), whether it holds any of the
+// instructions.
+func blocksOf(f *ssa.Function, instrs []ssa.Instruction) []bool {
+	blocks := make([]bool, len(f.Blocks))
+	for _, instr := range instrs {
+		blocks[instr.Block().Index] = true
+	}
+	return blocks
+}
+
+// reaches returns whether a block matching target can be reached from the successors of from
+// without going through a blocked block.
+func reaches(from *ssa.BasicBlock, blocked []bool, target func(*ssa.BasicBlock) bool) bool {
+	seen := make([]bool, len(blocked))
+	stack := []*ssa.BasicBlock{from}
+	for len(stack) > 0 {
+		b := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		for _, succ := range b.Succs {
+			if blocked[succ.Index] {
+				continue
+			}
+			if target(succ) {
+				return true
+			}
+			if !seen[succ.Index] {
+				seen[succ.Index] = true
+				stack = append(stack, succ)
+			}
+		}
+	}
+	return false
+}
+
+func endsInPanic(b *ssa.BasicBlock) bool {
+	if len(b.Instrs) == 0 {
+		return false
+	}
+	_, ok := b.Instrs[len(b.Instrs)-1].(*ssa.Panic)
+	return ok
+}
+
+func instrIndex(instr ssa.Instruction) int {
+	return slices.Index(instr.Block().Instrs, instr)
+}
+
+// firstUse returns the position of the first of the uses on a path through the function. That's
+// one that isn't preceded by any of the others on every path to it; if there are several, it's the
+// first one in the source.
+func firstUse(uses []ssa.Instruction) token.Pos {
+	var first token.Pos
+	for _, u := range uses {
+		if slices.ContainsFunc(uses, func(other ssa.Instruction) bool { return other != u && dominates(other, u) }) {
+			continue
+		}
+
+		if pos := instrPos(u); pos.IsValid() && (!first.IsValid() || pos < first) {
+			first = pos
+		}
+	}
+
+	return first
+}
+
+// instrPos returns the position of the instruction. Some instructions, like the implicit selection
+// of an embedded field, don't have one, in which case it's the position of the first instruction
+// that uses its value that does.
+func instrPos(instr ssa.Instruction) token.Pos {
+	if instr.Pos().IsValid() {
+		return instr.Pos()
+	}
+
+	val, ok := instr.(ssa.Value)
+	if !ok {
+		return token.NoPos
+	}
+
+	for _, ref := range *val.Referrers() {
+		if pos := instrPos(ref); pos.IsValid() {
+			return pos
+		}
+	}
+
+	return token.NoPos
+}
@@ -0,0 +1,227 @@
+package assertexpectations
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"slices"
+	"strings"
+
+	"github.com/cszczepaniak/gomockcheck/analyzers/internal/typeutils"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ssa"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+// checkCleanupOrder reports cleanup functions (passed to t.Cleanup or deferred) that call other
+// functions before AssertExpectations. If one of those calls panics (e.g. closing a database that
+// was never opened), the rest of the cleanup is skipped, and so are the assertions.
+func (r *runner) checkCleanupOrder(pass *analysis.Pass, closure *ssa.MakeClosure) {
+	isCleanup := false
+	for _, ref := range *closure.Referrers() {
+		if isTCleanupOrDefer(ref) {
+			isCleanup = true
+			break
+		}
+	}
+	if !isCleanup {
+		return
+	}
+
+	lit, ok := closure.Fn.(*ssa.Function).Syntax().(*ast.FuncLit)
+	if !ok {
+		return
+	}
+
+	var (
+		report   *ast.CallExpr
+		moved    []ast.Stmt
+		fixable  = true
+		sawCall  = false
+		declared = make(map[types.Object]bool)
+	)
+	for _, stmt := range lit.Body.List {
+		hasOther, late := false, false
+		for _, call := range calls(pass.TypesInfo, stmt) {
+			if !r.isAssertion(pass.TypesInfo, call) {
+				hasOther = true
+				continue
+			}
+
+			if sawCall || hasOther {
+				late = true
+				if report == nil {
+					report = call
+				}
+			}
+		}
+
+		switch {
+		case late && hasOther:
+			// The statement calls something else along with the assertion, so we'd have to split it
+			// up to fix it.
+			fixable = false
+		case late:
+			moved = append(moved, stmt)
+			fixable = fixable && canMove(pass.TypesInfo, stmt, declared)
+		}
+
+		sawCall = sawCall || hasOther
+		ast.Inspect(stmt, func(n ast.Node) bool {
+			if id, ok := n.(*ast.Ident); ok && pass.TypesInfo.Defs[id] != nil {
+				declared[pass.TypesInfo.Defs[id]] = true
+			}
+			return true
+		})
+	}
+
+	if report == nil {
+		return
+	}
+
+	diag := analysis.Diagnostic{
+		Pos:     report.Pos(),
+		End:     report.End(),
+		Message: "AssertExpectations should be called first in the cleanup function; calls before it can panic and skip it",
+	}
+	if fixable {
+		if edits, ok := moveToTop(pass, lit, moved); ok {
+			diag.SuggestedFixes = []analysis.SuggestedFix{{
+				Message:   "move AssertExpectations to the top of the cleanup function",
+				TextEdits: edits,
+			}}
+		}
+	}
+	pass.Report(diag)
+}
+
+// moveToTop returns edits that move the statements to the top of the function, keeping them in
+// order. The comments before and after each statement move with it.
+func moveToTop(pass *analysis.Pass, lit *ast.FuncLit, moved []ast.Stmt) ([]analysis.TextEdit, bool) {
+	stmts := lit.Body.List
+	tokFile := pass.Fset.File(lit.Pos())
+	src, err := pass.ReadFile(tokFile.Name())
+	if err != nil {
+		return nil, false
+	}
+
+	sep := "\n" + strings.Repeat("\t", tokFile.Position(stmts[0].Pos()).Column-1)
+	switch tokFile.Line(lit.Body.Lbrace) {
+	case tokFile.Line(lit.Body.Rbrace):
+		sep = "; "
+	case tokFile.Line(stmts[0].Pos()):
+		// We don't know how to indent the statements.
+		return nil, false
+	}
+
+	var text strings.Builder
+	var edits []analysis.TextEdit
+	for _, stmt := range moved {
+		i := slices.Index(stmts, stmt)
+		next := lit.Body.Rbrace
+		if i+1 < len(stmts) {
+			next = stmts[i+1].Pos()
+		}
+
+		start := lineEnd(pass, stmts[i-1], stmt.Pos())
+		end := lineEnd(pass, stmt, next)
+		// Leave out the semicolon that separates the statement from the previous one.
+		stmtText := strings.TrimSpace(string(src[tokFile.Offset(start):tokFile.Offset(end)]))
+		text.WriteString(strings.TrimSpace(strings.TrimPrefix(stmtText, ";")) + sep)
+		edits = append(edits, analysis.TextEdit{Pos: start, End: end})
+	}
+
+	return append([]analysis.TextEdit{{
+		Pos:     stmts[0].Pos(),
+		End:     stmts[0].Pos(),
+		NewText: []byte(text.String()),
+	}}, edits...), true
+}
+
+// calls returns the calls that the statement makes before the end of the cleanup, in the order
+// they return. Deferred calls and calls in closures that aren't called don't count, and neither do
+// conversions.
+func calls(info *types.Info, stmt ast.Stmt) []*ast.CallExpr {
+	var calls []*ast.CallExpr
+	ast.Inspect(stmt, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.DeferStmt, *ast.FuncLit:
+			return false
+		case *ast.CallExpr:
+			if tv, ok := info.Types[n.Fun]; !ok || !tv.IsType() {
+				calls = append(calls, n)
+			}
+		}
+		return true
+	})
+
+	// A call returns after the calls in its arguments.
+	slices.SortFunc(calls, func(a, b *ast.CallExpr) int { return int(a.Rparen - b.Rparen) })
+	return calls
+}
+
+// isAssertion returns whether the call is to AssertExpectations on a mock, or to
+// mock.AssertExpectationsForObjects. Assertions don't panic when they fail, so they're fine to call
+// before other assertions.
+func (r *runner) isAssertion(info *types.Info, call *ast.CallExpr) bool {
+	fn, ok := typeutil.Callee(info, call).(*types.Func)
+	if !ok {
+		return false
+	}
+
+	if isAssertExpectationsForObjects(fn) {
+		return true
+	}
+
+	recv := fn.Signature().Recv()
+	return fn.Name() == "AssertExpectations" &&
+		recv != nil &&
+		r.isMockObj(typeutils.GetObjForPtrToNamedType(recv.Type()))
+}
+
+// canMove returns whether the statement does the same thing at the top of the function: it doesn't
+// use anything declared by the statements before it, and it doesn't return or jump anywhere.
+func canMove(info *types.Info, stmt ast.Stmt, declared map[types.Object]bool) bool {
+	ok := true
+	ast.Inspect(stmt, func(n ast.Node) bool {
+		if id, isIdent := n.(*ast.Ident); isIdent && declared[info.Uses[id]] {
+			ok = false
+		}
+		return ok
+	})
+
+	ast.Inspect(stmt, func(n ast.Node) bool {
+		switch n.(type) {
+		case *ast.FuncLit:
+			// Returning from a closure is fine.
+			return false
+		case *ast.ReturnStmt, *ast.BranchStmt:
+			ok = false
+		}
+		return ok
+	})
+
+	return ok
+}
+
+// lineEnd returns the end of the statement, or of the comment that follows it on the same line
+// before next, so that the comment stays with the statement.
+func lineEnd(pass *analysis.Pass, stmt ast.Stmt, next token.Pos) token.Pos {
+	end := stmt.End()
+	line := pass.Fset.Position(end).Line
+	for _, file := range pass.Files {
+		if file.FileStart > end || end > file.FileEnd {
+			continue
+		}
+
+		for _, group := range file.Comments {
+			for _, c := range group.List {
+				if c.Pos() >= end && c.Pos() < next && pass.Fset.Position(c.Pos()).Line == line {
+					end = c.End()
+				}
+			}
+		}
+	}
+
+	return end
+}
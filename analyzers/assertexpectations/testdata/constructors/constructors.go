@@ -0,0 +1,118 @@
+package constructors
+
+import (
+	"testing"
+
+	"example.com/constructors/dep"
+	"example.com/testutil"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockRepo is an autogenerated mock type for the Repo type
+type MockRepo struct {
+	mock.Mock
+}
+
+func (m *MockRepo) Get(id string) error {
+	ret := m.Called(id)
+	return ret.Error(0)
+}
+
+// NewMockRepo creates a new instance of MockRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockRepo(t interface { // want NewMockRepo:"registersCleanup"
+	mock.TestingT
+	Cleanup(func())
+}) *MockRepo {
+	mock := &MockRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+func NewMockRepo_NoCleanup() *MockRepo { // want NewMockRepo_NoCleanup:"noCleanup\\[0\\]"
+	return &MockRepo{}
+}
+
+func NewMockRepo_Defer(t testing.TB) *MockRepo { // want NewMockRepo_Defer:"noCleanup\\[0\\]"
+	m := &MockRepo{}
+	defer m.AssertExpectations(t)
+	return m
+}
+
+func NewMockRepo_Wrapped(t testing.TB) *MockRepo { // want NewMockRepo_Wrapped:"registersCleanup"
+	return NewMockRepo(t)
+}
+
+func NewMockRepo_WrappedNoCleanup() *MockRepo { // want NewMockRepo_WrappedNoCleanup:"noCleanup\\[0\\]"
+	m := NewMockRepo_NoCleanup()
+	return m
+}
+
+func NewMockRepo_RegistersWrapped(t testing.TB) *MockRepo { // want NewMockRepo_RegistersWrapped:"registersCleanup"
+	m := NewMockRepo_NoCleanup()
+	t.Cleanup(func() { m.AssertExpectations(t) })
+	return m
+}
+
+func NewMockRepo_CleanupHelper(t testing.TB) *MockRepo { // want NewMockRepo_CleanupHelper:"registersCleanup"
+	m := &MockRepo{}
+	testutil.ExpectAll(t, m)
+	return m
+}
+
+func NewMockRepo_ForObjects(t testing.TB) *MockRepo { // want NewMockRepo_ForObjects:"registersCleanup"
+	m := &MockRepo{}
+	t.Cleanup(func() { mock.AssertExpectationsForObjects(t, m) })
+	return m
+}
+
+func NewMockRepo_CleanupOnOneBranch(t testing.TB, cleanup bool) *MockRepo { // want NewMockRepo_CleanupOnOneBranch:"noCleanup\\[0\\]"
+	m := &MockRepo{}
+	if cleanup {
+		t.Cleanup(func() { m.AssertExpectations(t) })
+	}
+	return m
+}
+
+func NewMockRepo_CleanupWithoutAssertExpectations(t testing.TB) *MockRepo { // want NewMockRepo_CleanupWithoutAssertExpectations:"noCleanup\\[0\\]"
+	m := &MockRepo{}
+	t.Cleanup(func() { m.AssertCalled(t, "Get") }) // want "mocks must have an AssertExpectations registered in a defer or t.Cleanup"
+	return m
+}
+
+func NewMockRepos(t testing.TB) (*MockRepo, *MockRepo) { // want NewMockRepos:"noCleanup\\[1\\]"
+	registered := NewMockRepo(t)
+	return registered, &MockRepo{}
+}
+
+func NewMockRepos_Wrapped(t testing.TB) (*MockRepo, *MockRepo) { // want NewMockRepos_Wrapped:"noCleanup\\[1\\]"
+	return NewMockRepos(t)
+}
+
+func NewMockStore_Wrapped(t testing.TB) *dep.MockStore { // want NewMockStore_Wrapped:"registersCleanup"
+	return dep.NewMockStore(t)
+}
+
+func NewMockStore_WrappedNoCleanup() *dep.MockStore { // want NewMockStore_WrappedNoCleanup:"noCleanup\\[0\\]"
+	return dep.NewMockStore_NoCleanup()
+}
+
+func PutStores(t testing.TB) {
+	registered := dep.NewMockStore(t)
+	registered.On("Put", "key").Return(nil)
+
+	unregistered := dep.NewMockStore_NoCleanup()
+	unregistered.On("Put", "key").Return(nil) // want "mocks must have an AssertExpectations registered in a defer or t.Cleanup"
+}
+
+type Suite struct {
+	repo *MockRepo
+}
+
+// We can't tell where the mock comes from, so there's no fact.
+func (s *Suite) Repo() *MockRepo {
+	return s.repo
+}
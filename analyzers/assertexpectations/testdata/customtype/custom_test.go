@@ -8,43 +8,43 @@ type MyMock struct {
 	MyMockType
 }
 
-func newMyMock(t testing.TB) *MyMock {
+func newMyMock(t testing.TB) *MyMock { // want newMyMock:"registersCleanup"
 	m := &MyMock{}
 	t.Cleanup(func() { m.AssertExpectations(t) })
 	return m
 }
 
-func newMyMock_NoCleanup() *MyMock {
-	return &MyMock{} // want "mocks must have an AssertExpectations registered in a defer or t.Cleanup"
+func newMyMock_NoCleanup() *MyMock { // want newMyMock_NoCleanup:"noCleanup\\[0\\]"
+	return &MyMock{}
 }
 
-func newMyMock_NoCleanup_Defer(t testing.TB) *MyMock {
-	m := &MyMock{} // want "mocks must have an AssertExpectations registered in a defer or t.Cleanup"
+func newMyMock_NoCleanup_Defer(t testing.TB) *MyMock { // want newMyMock_NoCleanup_Defer:"noCleanup\\[0\\]"
+	m := &MyMock{}
 	defer m.AssertExpectations(t)
 	return m
 }
 
-func newMyMock_NoCleanup_Complicated(t testing.TB) (*MyMock, *MyMock) {
-	m1 := &MyMock{} // want "mocks must have an AssertExpectations registered in a defer or t.Cleanup"
+func newMyMock_NoCleanup_Complicated(t testing.TB) (*MyMock, *MyMock) { // want newMyMock_NoCleanup_Complicated:"noCleanup\\[1\\]"
+	m1 := &MyMock{}
 	defer m1.AssertExpectations(t)
 
 	m2 := &MyMock{}
 	t.Cleanup(func() { m2.AssertExpectations(t) })
 
-	m3 := &MyMock{} // want "mocks must have an AssertExpectations registered in a defer or t.Cleanup"
-	m3.Called()
+	m3 := &MyMock{}
+	m3.Called() // want "mocks must have an AssertExpectations registered in a defer or t.Cleanup"
 
 	return m2, m1
 }
 
 func Test_NoAssertion(t *testing.T) {
-	a := &MyMock{} // want "mocks must have an AssertExpectations registered in a defer or t.Cleanup"
-	a.Called()
+	a := &MyMock{}
+	a.Called() // want "mocks must have an AssertExpectations registered in a defer or t.Cleanup"
 }
 
 func Test_NoAssertion_AccessField(t *testing.T) {
-	a := &MyMock{} // want "mocks must have an AssertExpectations registered in a defer or t.Cleanup"
-	a.MyMockType.Called()
+	a := &MyMock{}
+	a.MyMockType.Called() // want "mocks must have an AssertExpectations registered in a defer or t.Cleanup"
 }
 
 func Test_DeferAssert(t *testing.T) {
@@ -60,8 +60,8 @@ func Test_DeferAssert_OnField(t *testing.T) {
 }
 
 func Test_DeferAssert_AfterOtherUsage(t *testing.T) {
-	a := &MyMock{} // want "mocks must have an AssertExpectations registered in a defer or t.Cleanup"
-	a.Called()
+	a := &MyMock{}
+	a.Called() // want "mocks must have an AssertExpectations registered in a defer or t.Cleanup"
 	defer a.AssertExpectations(t)
 }
 
@@ -78,8 +78,8 @@ func Test_TCleanup_OnField(t *testing.T) {
 }
 
 func Test_TCleanup_AfterOtherUsage(t *testing.T) {
-	a := &MyMock{} // want "mocks must have an AssertExpectations registered in a defer or t.Cleanup"
-	a.Called()
+	a := &MyMock{}
+	a.Called() // want "mocks must have an AssertExpectations registered in a defer or t.Cleanup"
 	t.Cleanup(func() { a.AssertExpectations(t) })
 }
 
@@ -89,6 +89,6 @@ func Test_GetMockFromFunction(t *testing.T) {
 }
 
 func Test_NormalCallToAssertExpectations(t *testing.T) {
-	a := &MyMock{} // want "mocks must have an AssertExpectations registered in a defer or t.Cleanup"
-	a.AssertExpectations(t)
+	a := &MyMock{}
+	a.AssertExpectations(t) // want "mocks must have an AssertExpectations registered in a defer or t.Cleanup"
 }
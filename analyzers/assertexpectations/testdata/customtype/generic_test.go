@@ -0,0 +1,21 @@
+package customtype
+
+import "testing"
+
+type GenericMockType[T any] struct{}
+
+func (m *GenericMockType[T]) AssertExpectations(t testing.TB) {}
+func (m *GenericMockType[T]) Called(...any)                   {}
+
+type GenericMock[T any] struct {
+	GenericMockType[T]
+}
+
+func Test_GenericCustomMock(t *testing.T) {
+	ok := &GenericMock[int]{}
+	defer ok.AssertExpectations(t)
+	ok.Called()
+
+	bad := &GenericMock[string]{}
+	bad.Called() // want "mocks must have an AssertExpectations registered in a defer or t.Cleanup"
+}
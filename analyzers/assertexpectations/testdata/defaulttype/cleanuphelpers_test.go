@@ -0,0 +1,93 @@
+package defaulttype
+
+import (
+	"testing"
+
+	"example.com/testutil"
+)
+
+func registerMock(t testing.TB, m *MyMock) { // want registerMock:"cleanupHelper\\[1\\]"
+	t.Cleanup(func() { m.AssertExpectations(t) })
+}
+
+func Test_CleanupHelper_Local(t *testing.T) {
+	a := &MyMock{}
+	registerMock(t, a)
+	a.Called()
+}
+
+func Test_CleanupHelper_Local_AfterUse(t *testing.T) {
+	a := &MyMock{}
+	a.Called() // want "mocks must have an AssertExpectations registered in a defer or t.Cleanup"
+	registerMock(t, a)
+}
+
+func Test_CleanupHelper_SeveralParams(t *testing.T) {
+	a := &MyMock{}
+	b := &MyMock{}
+	testutil.RegisterMocks(t, a, b)
+	a.Called()
+	b.Called()
+}
+
+func Test_CleanupHelper_Variadic(t *testing.T) {
+	a := &MyMock{}
+	b := &MyMock{}
+	testutil.ExpectAll(t, a, b)
+	a.Called()
+	b.Called()
+}
+
+func Test_CleanupHelper_Variadic_Wrapped(t *testing.T) {
+	a := &MyMock{}
+	b := &MyMock{}
+	testutil.ExpectAll_Wrapped(t, a, b)
+	testutil.Expect_Wrapped(t, a)
+	a.Called()
+	b.Called()
+}
+
+func Test_CleanupHelper_Slice(t *testing.T) {
+	a := &MyMock{}
+	b := &MyMock{}
+	testutil.ExpectSlice(t, []testutil.Expecter{a, b})
+	a.Called()
+	b.Called()
+}
+
+func Test_CleanupHelper_Method(t *testing.T) {
+	var s testutil.Suite
+	a := &MyMock{}
+	s.Expect(a)
+	a.Called()
+}
+
+func Test_CleanupHelper_Captured(t *testing.T) {
+	a := &MyMock{}
+	testutil.ExpectAll(t, a)
+	func() {
+		a.Called()
+	}()
+}
+
+func Test_CleanupHelper_NotRegistered(t *testing.T) {
+	a := &MyMock{}
+	testutil.ExpectDeferred(t, a) // want "mocks must have an AssertExpectations registered in a defer or t.Cleanup"
+	a.Called()
+
+	b := &MyMock{}
+	testutil.ExpectSometimes(t, b, true) // want "mocks must have an AssertExpectations registered in a defer or t.Cleanup"
+	b.Called()
+
+	c := &MyMock{}
+	testutil.CleanupWithoutAssertExpectations(t, c) // want "mocks must have an AssertExpectations registered in a defer or t.Cleanup"
+	c.Called()
+}
+
+func Test_CleanupHelper_OnlyOneRegistered(t *testing.T) {
+	a := &MyMock{}
+	b := &MyMock{}
+	testutil.ExpectAll(t, a)
+	a.Called()
+	b.Called() // want "mocks must have an AssertExpectations registered in a defer or t.Cleanup"
+}
@@ -0,0 +1,89 @@
+package defaulttype
+
+import (
+	"testing"
+
+	"example.com/constructors"
+)
+
+func Test_Constructor_RegistersCleanup(t *testing.T) {
+	repo := constructors.NewMockRepo(t)
+	repo.On("Get", "id").Return(nil)
+	repo.Get("id")
+}
+
+func Test_Constructor_Wrapped(t *testing.T) {
+	repo := constructors.NewMockRepo_Wrapped(t)
+	repo.Get("id")
+
+	repo = constructors.NewMockRepo_RegistersWrapped(t)
+	repo.Get("id")
+
+	repo = constructors.NewMockRepo_CleanupHelper(t)
+	repo.Get("id")
+}
+
+func Test_Constructor_NoCleanup(t *testing.T) {
+	repo := constructors.NewMockRepo_NoCleanup()
+	repo.On("Get", "id").Return(nil) // want "mocks must have an AssertExpectations registered in a defer or t.Cleanup"
+	repo.Get("id")
+}
+
+func Test_Constructor_NoCleanup_Used(t *testing.T) {
+	constructors.NewMockRepo_Defer(t).Get("id") // want "mocks must have an AssertExpectations registered in a defer or t.Cleanup"
+}
+
+func Test_Constructor_NoCleanup_Wrapped(t *testing.T) {
+	repo := constructors.NewMockRepo_WrappedNoCleanup()
+	repo.Get("id") // want "mocks must have an AssertExpectations registered in a defer or t.Cleanup"
+}
+
+func Test_Constructor_NoCleanup_OneBranch(t *testing.T) {
+	repo := constructors.NewMockRepo_CleanupOnOneBranch(t, true)
+	repo.Get("id") // want "mocks must have an AssertExpectations registered in a defer or t.Cleanup"
+}
+
+func Test_Constructor_NoCleanup_RegisteredByCaller(t *testing.T) {
+	repo := constructors.NewMockRepo_NoCleanup()
+	defer repo.AssertExpectations(t)
+	repo.Get("id")
+}
+
+func Test_Constructor_NoCleanup_RegisteredByCallerInCleanup(t *testing.T) {
+	repo := constructors.NewMockRepo_NoCleanup()
+	t.Cleanup(func() { repo.AssertExpectations(t) })
+	repo.Get("id")
+}
+
+func Test_Constructor_NoCleanup_Captured(t *testing.T) {
+	repo := constructors.NewMockRepo_NoCleanup()
+	func() {
+		repo.Get("id")
+	}() // want "mocks must have an AssertExpectations registered in a defer or t.Cleanup"
+}
+
+func Test_Constructor_SeveralMocks(t *testing.T) {
+	registered, unregistered := constructors.NewMockRepos_Wrapped(t)
+	registered.Get("id")
+	unregistered.Get("id") // want "mocks must have an AssertExpectations registered in a defer or t.Cleanup"
+}
+
+func Test_Constructor_Getter(t *testing.T) {
+	var s constructors.Suite
+	s.Repo().Get("id")
+}
+
+func Test_Constructor_Local(t *testing.T) {
+	a := newMyMock_NoCleanup()
+	a.Called() // want "mocks must have an AssertExpectations registered in a defer or t.Cleanup"
+
+	b := newMyMock_NoCleanup_Defer(t)
+	b.Called() // want "mocks must have an AssertExpectations registered in a defer or t.Cleanup"
+
+	c := newGenericMock_NoCleanup[int]()
+	defer c.AssertExpectations(t)
+	c.Called()
+
+	d := newGenericMock[int](t)
+	d.Called()
+}
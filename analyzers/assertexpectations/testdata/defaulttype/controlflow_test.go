@@ -0,0 +1,120 @@
+package defaulttype
+
+import "testing"
+
+func cond() bool { return true }
+
+func Test_ControlFlow_UseInBranch_DeferInOtherBranch(t *testing.T) {
+	a := &MyMock{}
+	if cond() {
+		a.Called() // want "mocks must have an AssertExpectations registered in a defer or t.Cleanup"
+	} else {
+		defer a.AssertExpectations(t)
+	}
+}
+
+func Test_ControlFlow_DeferInBranch_UseInOtherBranch(t *testing.T) {
+	a := &MyMock{}
+	if cond() {
+		defer a.AssertExpectations(t)
+	} else {
+		a.Called() // want "mocks must have an AssertExpectations registered in a defer or t.Cleanup"
+	}
+}
+
+func Test_ControlFlow_DeferBeforeBranch(t *testing.T) {
+	a := &MyMock{}
+	defer a.AssertExpectations(t)
+	if cond() {
+		a.Called()
+	} else {
+		a.Called()
+	}
+}
+
+func Test_ControlFlow_CleanupInBranch_UseAfter(t *testing.T) {
+	a := &MyMock{}
+	if cond() {
+		t.Cleanup(func() { a.AssertExpectations(t) })
+		a.Called()
+	}
+	a.Called() // want "mocks must have an AssertExpectations registered in a defer or t.Cleanup"
+}
+
+func Test_ControlFlow_UseInLoop(t *testing.T) {
+	a := &MyMock{}
+	defer a.AssertExpectations(t)
+	for range 3 {
+		a.Called()
+	}
+}
+
+func Test_ControlFlow_RegisterInLoop(t *testing.T) {
+	a := &MyMock{}
+	for range 3 {
+		a.Called() // want "mocks must have an AssertExpectations registered in a defer or t.Cleanup"
+		t.Cleanup(func() { a.AssertExpectations(t) })
+	}
+}
+
+func Test_ControlFlow_SetupBeforeDeferAfterBranch(t *testing.T) {
+	a := &MyMock{}
+	a.On("Foo")
+	if cond() {
+		a.On("Bar")
+	}
+	defer a.AssertExpectations(t)
+	a.Called()
+}
+
+func Test_ControlFlow_SetupBeforeDeferInBranch(t *testing.T) {
+	a := &MyMock{}
+	a.On("Foo") // want "mocks must have an AssertExpectations registered in a defer or t.Cleanup"
+	if cond() {
+		defer a.AssertExpectations(t)
+	}
+}
+
+func Test_ControlFlow_RegisterInBothBranches(t *testing.T) {
+	a := &MyMock{}
+	a.On("Foo")
+	if cond() {
+		t.Cleanup(func() { a.AssertExpectations(t) })
+	} else {
+		defer a.AssertExpectations(t)
+	}
+	a.Called()
+}
+
+func Test_ControlFlow_RegisterInOneBranch(t *testing.T) {
+	a := &MyMock{}
+	a.On("Foo") // want "mocks must have an AssertExpectations registered in a defer or t.Cleanup"
+	if cond() {
+		t.Cleanup(func() { a.AssertExpectations(t) })
+	} else {
+		a.On("Bar")
+	}
+	a.Called()
+}
+
+func Test_ControlFlow_SetupBeforePanic(t *testing.T) {
+	a := &MyMock{}
+	a.On("Foo")
+	if !cond() {
+		panic("this path doesn't need to assert expectations")
+	}
+	t.Cleanup(func() { a.AssertExpectations(t) })
+	a.Called()
+}
+
+func Test_ControlFlow_FirstUseOnPath(t *testing.T) {
+	a := &MyMock{}
+	if cond() {
+		a.On("Foo") // want "mocks must have an AssertExpectations registered in a defer or t.Cleanup"
+		a.Called()
+		a.Called()
+		return
+	}
+	defer a.AssertExpectations(t)
+	a.Called()
+}
@@ -10,43 +10,43 @@ type MyMock struct {
 	mock.Mock
 }
 
-func newMyMock(t testing.TB) *MyMock {
+func newMyMock(t testing.TB) *MyMock { // want newMyMock:"registersCleanup"
 	m := &MyMock{}
 	t.Cleanup(func() { m.AssertExpectations(t) })
 	return m
 }
 
-func newMyMock_NoCleanup() *MyMock {
-	return &MyMock{} // want "mocks must have an AssertExpectations registered in a defer or t.Cleanup"
+func newMyMock_NoCleanup() *MyMock { // want newMyMock_NoCleanup:"noCleanup\\[0\\]"
+	return &MyMock{}
 }
 
-func newMyMock_NoCleanup_Defer(t testing.TB) *MyMock {
-	m := &MyMock{} // want "mocks must have an AssertExpectations registered in a defer or t.Cleanup"
+func newMyMock_NoCleanup_Defer(t testing.TB) *MyMock { // want newMyMock_NoCleanup_Defer:"noCleanup\\[0\\]"
+	m := &MyMock{}
 	defer m.AssertExpectations(t)
 	return m
 }
 
-func newMyMock_NoCleanup_Complicated(t testing.TB) (*MyMock, *MyMock) {
-	m1 := &MyMock{} // want "mocks must have an AssertExpectations registered in a defer or t.Cleanup"
+func newMyMock_NoCleanup_Complicated(t testing.TB) (*MyMock, *MyMock) { // want newMyMock_NoCleanup_Complicated:"noCleanup\\[1\\]"
+	m1 := &MyMock{}
 	defer m1.AssertExpectations(t)
 
 	m2 := &MyMock{}
 	t.Cleanup(func() { m2.AssertExpectations(t) })
 
-	m3 := &MyMock{} // want "mocks must have an AssertExpectations registered in a defer or t.Cleanup"
-	m3.Called()
+	m3 := &MyMock{}
+	m3.Called() // want "mocks must have an AssertExpectations registered in a defer or t.Cleanup"
 
 	return m2, m1
 }
 
 func Test_NoAssertion(t *testing.T) {
-	a := &MyMock{} // want "mocks must have an AssertExpectations registered in a defer or t.Cleanup"
-	a.Called()
+	a := &MyMock{}
+	a.Called() // want "mocks must have an AssertExpectations registered in a defer or t.Cleanup"
 }
 
 func Test_NoAssertion_AccessField(t *testing.T) {
-	a := &MyMock{} // want "mocks must have an AssertExpectations registered in a defer or t.Cleanup"
-	a.Mock.Called()
+	a := &MyMock{}
+	a.Mock.Called() // want "mocks must have an AssertExpectations registered in a defer or t.Cleanup"
 }
 
 func Test_DeferAssert(t *testing.T) {
@@ -76,8 +76,8 @@ func Test_DeferAssert_OnField(t *testing.T) {
 }
 
 func Test_DeferAssert_AfterOtherUsage(t *testing.T) {
-	a := &MyMock{} // want "mocks must have an AssertExpectations registered in a defer or t.Cleanup"
-	a.Called()
+	a := &MyMock{}
+	a.Called() // want "mocks must have an AssertExpectations registered in a defer or t.Cleanup"
 	defer a.AssertExpectations(t)
 }
 
@@ -106,9 +106,9 @@ func Test_Defer_WithClosure_TwoMocks(t *testing.T) {
 
 func Test_Defer_WithClosure_TwoMocks_OnlyEffectiveForOne(t *testing.T) {
 	a := &MyMock{}
-	b := &MyMock{} // want "mocks must have an AssertExpectations registered in a defer or t.Cleanup"
+	b := &MyMock{}
 
-	b.Called()
+	b.Called() // want "mocks must have an AssertExpectations registered in a defer or t.Cleanup"
 
 	defer func() {
 		a.AssertExpectations(t)
@@ -120,9 +120,9 @@ func Test_Defer_WithClosure_TwoMocks_OnlyEffectiveForOne(t *testing.T) {
 }
 
 func Test_Defer_WithClosure_ButWrongCalls(t *testing.T) {
-	a := &MyMock{} // want "mocks must have an AssertExpectations registered in a defer or t.Cleanup"
+	a := &MyMock{}
 
-	defer func() {
+	defer func() { // want "mocks must have an AssertExpectations registered in a defer or t.Cleanup"
 		a.AssertCalled(t, "")
 	}()
 
@@ -182,16 +182,16 @@ func Test_TCleanup_MoreThingsInTheCleanup(t *testing.T) {
 }
 
 func Test_TCleanup_WithoutAssertExpectations(t *testing.T) {
-	a := &MyMock{} // want "mocks must have an AssertExpectations registered in a defer or t.Cleanup"
-	t.Cleanup(func() {
+	a := &MyMock{}
+	t.Cleanup(func() { // want "mocks must have an AssertExpectations registered in a defer or t.Cleanup"
 		a.Called()
 	})
 	a.Called()
 }
 
 func Test_TCleanup_AfterOtherUsage(t *testing.T) {
-	a := &MyMock{} // want "mocks must have an AssertExpectations registered in a defer or t.Cleanup"
-	a.Called()
+	a := &MyMock{}
+	a.Called() // want "mocks must have an AssertExpectations registered in a defer or t.Cleanup"
 	t.Cleanup(func() { a.AssertExpectations(t) })
 }
 
@@ -214,8 +214,8 @@ func Test_TCleanup_DeclareSeparately_WithAnotherMockInBetweenThatFails(t *testin
 		a.AssertExpectations(t)
 	}
 
-	b := &MyMock{} // want "mocks must have an AssertExpectations registered in a defer or t.Cleanup"
-	b.Called()
+	b := &MyMock{}
+	b.Called() // want "mocks must have an AssertExpectations registered in a defer or t.Cleanup"
 
 	t.Cleanup(fn)
 
@@ -239,8 +239,8 @@ func Test_TCleanup_TwoMocksCleanedUp(t *testing.T) {
 
 func Test_TCleanup_TwoMocksCleanedUp_OnlyEffectiveForOne(t *testing.T) {
 	a := &MyMock{}
-	b := &MyMock{} // want "mocks must have an AssertExpectations registered in a defer or t.Cleanup"
-	b.Called()
+	b := &MyMock{}
+	b.Called() // want "mocks must have an AssertExpectations registered in a defer or t.Cleanup"
 
 	fn := func() {
 		a.AssertExpectations(t)
@@ -259,13 +259,13 @@ func Test_GetMockFromFunction(t *testing.T) {
 }
 
 func Test_NormalCallToAssertExpectations(t *testing.T) {
-	a := &MyMock{} // want "mocks must have an AssertExpectations registered in a defer or t.Cleanup"
-	a.AssertExpectations(t)
+	a := &MyMock{}
+	a.AssertExpectations(t) // want "mocks must have an AssertExpectations registered in a defer or t.Cleanup"
 }
 
 func Benchmark_ItShouldWorkHereToo(b *testing.B) {
-	a := &MyMock{} // want "mocks must have an AssertExpectations registered in a defer or t.Cleanup"
-	a.Called()
+	a := &MyMock{}
+	a.Called() // want "mocks must have an AssertExpectations registered in a defer or t.Cleanup"
 }
 
 func Benchmark_ItShouldWorkHereToo_NoProblem(b *testing.B) {
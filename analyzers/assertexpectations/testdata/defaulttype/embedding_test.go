@@ -0,0 +1,56 @@
+package defaulttype
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type BaseMock struct {
+	mock.Mock
+}
+
+type EmbedsBaseMock struct {
+	BaseMock
+}
+
+type EmbedsMockPointer struct {
+	*mock.Mock
+}
+
+type testifyMock = mock.Mock
+
+type EmbedsAliasedMock struct {
+	testifyMock
+}
+
+type AliasedMock = EmbedsBaseMock
+
+func Test_EmbeddedThroughBaseMock(t *testing.T) {
+	a := &EmbedsBaseMock{}
+	defer a.AssertExpectations(t)
+
+	b := &EmbedsBaseMock{}
+	b.Called() // want "mocks must have an AssertExpectations registered in a defer or t.Cleanup"
+}
+
+func Test_EmbeddedByPointer(t *testing.T) {
+	a := &EmbedsMockPointer{Mock: &mock.Mock{}}
+	t.Cleanup(func() { a.AssertExpectations(t) })
+
+	b := &EmbedsMockPointer{Mock: &mock.Mock{}}
+	b.Called() // want "mocks must have an AssertExpectations registered in a defer or t.Cleanup"
+}
+
+func Test_EmbeddedThroughAlias(t *testing.T) {
+	a := &EmbedsAliasedMock{}
+	a.Called() // want "mocks must have an AssertExpectations registered in a defer or t.Cleanup"
+}
+
+func Test_AliasedMock(t *testing.T) {
+	a := &AliasedMock{}
+	defer a.AssertExpectations(t)
+
+	b := &AliasedMock{}
+	b.Called() // want "mocks must have an AssertExpectations registered in a defer or t.Cleanup"
+}
@@ -0,0 +1,76 @@
+package defaulttype
+
+import (
+	"testing"
+
+	"example.com/testutil"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_ForObjects_Defer(t *testing.T) {
+	a := &MyMock{}
+	b := &MyMock{}
+	defer mock.AssertExpectationsForObjects(t, a, b)
+	a.Called()
+	b.Called()
+}
+
+func Test_ForObjects_Defer_AfterUse(t *testing.T) {
+	a := &MyMock{}
+	a.Called() // want "mocks must have an AssertExpectations registered in a defer or t.Cleanup"
+	defer mock.AssertExpectationsForObjects(t, a)
+}
+
+func Test_ForObjects_TCleanup(t *testing.T) {
+	a := &MyMock{}
+	b := &MyMock{}
+	t.Cleanup(func() { mock.AssertExpectationsForObjects(t, a, b) })
+	a.Called()
+	b.Called()
+}
+
+func Test_ForObjects_DeferClosure(t *testing.T) {
+	a := &MyMock{}
+	defer func() {
+		mock.AssertExpectationsForObjects(t, a)
+	}()
+	a.Called()
+}
+
+func Test_ForObjects_Slice(t *testing.T) {
+	a := &MyMock{}
+	b := &MyMock{}
+	t.Cleanup(func() { mock.AssertExpectationsForObjects(t, []interface{}{a, b}...) })
+	a.Called()
+	b.Called()
+}
+
+func Test_ForObjects_OnlyOne(t *testing.T) {
+	a := &MyMock{}
+	b := &MyMock{}
+	t.Cleanup(func() { mock.AssertExpectationsForObjects(t, a) })
+	a.Called()
+	b.Called() // want "mocks must have an AssertExpectations registered in a defer or t.Cleanup"
+}
+
+func Test_ForObjects_NotDeferred(t *testing.T) {
+	a := &MyMock{}
+	mock.AssertExpectationsForObjects(t, a) // want "mocks must have an AssertExpectations registered in a defer or t.Cleanup"
+}
+
+func newMyMock_ForObjects(t testing.TB) *MyMock { // want newMyMock_ForObjects:"registersCleanup"
+	m := &MyMock{}
+	t.Cleanup(func() { mock.AssertExpectationsForObjects(t, m) })
+	return m
+}
+
+func Test_ForObjects_Constructor(t *testing.T) {
+	a := newMyMock_ForObjects(t)
+	a.Called()
+}
+
+func Test_ForObjects_Helper(t *testing.T) {
+	a := &MyMock{}
+	testutil.ExpectForObjects(t, a)
+	a.Called()
+}
@@ -0,0 +1,46 @@
+package defaulttype
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type GenericMock[T any] struct {
+	mock.Mock
+}
+
+func (m *GenericMock[T]) Get(id string) (T, error) {
+	args := m.Called(id)
+	return args.Get(0).(T), args.Error(1)
+}
+
+func newGenericMock[T any](t testing.TB) *GenericMock[T] { // want newGenericMock:"registersCleanup"
+	m := &GenericMock[T]{}
+	t.Cleanup(func() { m.AssertExpectations(t) })
+	return m
+}
+
+func newGenericMock_NoCleanup[T any]() *GenericMock[T] { // want newGenericMock_NoCleanup:"noCleanup\\[0\\]"
+	return &GenericMock[T]{}
+}
+
+func Test_Generic(t *testing.T) {
+	ints := &GenericMock[int]{}
+	t.Cleanup(func() { ints.AssertExpectations(t) })
+	ints.On("Get", "id").Return(1, nil)
+
+	strs := &GenericMock[string]{}
+	defer strs.AssertExpectations(t)
+	strs.On("Get", "id").Return("a", nil)
+
+	_ = newGenericMock[bool](t)
+}
+
+func Test_Generic_NoAssertion(t *testing.T) {
+	ints := &GenericMock[int]{}
+	ints.Called() // want "mocks must have an AssertExpectations registered in a defer or t.Cleanup"
+
+	strs := &GenericMock[string]{}
+	strs.Called() // want "mocks must have an AssertExpectations registered in a defer or t.Cleanup"
+}
@@ -0,0 +1,41 @@
+package defaulttype
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// ShadowingMock mocks an interface whose methods have the same names as some of mock.Mock's.
+type ShadowingMock struct {
+	mock.Mock
+}
+
+func (m *ShadowingMock) AssertExpectations(t mock.TestingT) bool {
+	return m.Mock.Called(t).Bool(0)
+}
+
+func (m *ShadowingMock) Test(t mock.TestingT) {
+	m.Mock.Called(t)
+}
+
+func Test_Shadowed_ThroughMock(t *testing.T) {
+	a := &ShadowingMock{}
+	defer a.Mock.AssertExpectations(t)
+
+	b := &ShadowingMock{}
+	b.Mock.Test(t)
+	t.Cleanup(func() { b.Mock.AssertExpectations(t) })
+}
+
+func Test_Shadowed_CallsMockedMethod(t *testing.T) {
+	a := &ShadowingMock{}
+	defer a.AssertExpectations(t) // want "mocks must have an AssertExpectations registered in a defer or t.Cleanup"
+
+	b := &ShadowingMock{}
+	t.Cleanup(func() { b.AssertExpectations(t) }) // want "mocks must have an AssertExpectations registered in a defer or t.Cleanup"
+
+	c := &ShadowingMock{}
+	c.Test(t) // want "mocks must have an AssertExpectations registered in a defer or t.Cleanup"
+	defer c.Mock.AssertExpectations(t)
+}
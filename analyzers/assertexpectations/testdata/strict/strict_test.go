@@ -0,0 +1,159 @@
+package strict
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MyMock struct {
+	mock.Mock
+}
+
+type DB struct{}
+
+func (DB) Close() error { return nil }
+
+func Test_AssertFirst(t *testing.T) {
+	a := &MyMock{}
+	_, cancel := context.WithCancel(context.Background())
+	t.Cleanup(func() {
+		a.AssertExpectations(t)
+		cancel()
+	})
+	a.Called()
+}
+
+func Test_CallBeforeAssert(t *testing.T) {
+	a := &MyMock{}
+	_, cancel := context.WithCancel(context.Background())
+	t.Cleanup(func() {
+		cancel()
+		a.AssertExpectations(t) // want "AssertExpectations should be called first in the cleanup function; calls before it can panic and skip it"
+	})
+	a.Called()
+}
+
+func Test_CallBeforeAssert_Defer(t *testing.T) {
+	a := &MyMock{}
+	var db DB
+	defer func() {
+		_ = db.Close()
+		a.AssertExpectations(t) // want "AssertExpectations should be called first in the cleanup function; calls before it can panic and skip it"
+	}()
+	a.Called()
+}
+
+func Test_CallBeforeAssert_SeveralMocks(t *testing.T) {
+	a := &MyMock{}
+	b := &MyMock{}
+	var db DB
+	t.Cleanup(func() {
+		a.AssertExpectations(t)
+		db.Close()
+		b.AssertExpectations(t) // want "AssertExpectations should be called first in the cleanup function; calls before it can panic and skip it"
+		mock.AssertExpectationsForObjects(t, a, b)
+	})
+	a.Called()
+	b.Called()
+}
+
+func Test_CallBeforeAssert_OneLine(t *testing.T) {
+	a := &MyMock{}
+	var db DB
+	t.Cleanup(func() { db.Close(); a.AssertExpectations(t) }) // want "AssertExpectations should be called first in the cleanup function; calls before it can panic and skip it"
+	a.Called()
+}
+
+func Test_CallBeforeAssert_DeclaredSeparately(t *testing.T) {
+	a := &MyMock{}
+	var db DB
+	fn := func() {
+		db.Close()
+		a.AssertExpectations(t) // want "AssertExpectations should be called first in the cleanup function; calls before it can panic and skip it"
+	}
+	t.Cleanup(fn)
+	a.Called()
+}
+
+func Test_CallBeforeAssert_Comments(t *testing.T) {
+	a := &MyMock{}
+	b := &MyMock{}
+	var db DB
+	t.Cleanup(func() {
+		db.Close() // Close the database.
+		// Check a.
+		a.AssertExpectations(t) // want "AssertExpectations should be called first in the cleanup function; calls before it can panic and skip it"
+		// Check b.
+		b.AssertExpectations(
+			t,
+		) // Done.
+	})
+	a.Called()
+	b.Called()
+}
+
+func Test_CallBeforeAssert_Nested(t *testing.T) {
+	a := &MyMock{}
+	var db DB
+	t.Cleanup(func() {
+		db.Close()
+		if a != nil {
+			a.AssertExpectations(t) // want "AssertExpectations should be called first in the cleanup function; calls before it can panic and skip it"
+		}
+	})
+	a.Called()
+}
+
+func Test_CallBeforeAssert_NestedAfterCall(t *testing.T) {
+	a := &MyMock{}
+	var db DB
+	t.Cleanup(func() {
+		if a != nil {
+			db.Close()
+			a.AssertExpectations(t) // want "AssertExpectations should be called first in the cleanup function; calls before it can panic and skip it"
+		}
+	})
+	a.Called()
+}
+
+func Test_CallBeforeAssert_UsesEarlierDeclaration(t *testing.T) {
+	a := &MyMock{}
+	var db DB
+	t.Cleanup(func() {
+		m := a
+		db.Close()
+		m.AssertExpectations(t) // want "AssertExpectations should be called first in the cleanup function; calls before it can panic and skip it"
+	})
+	a.Called()
+}
+
+func Test_NoCallsBeforeAssert(t *testing.T) {
+	a := &MyMock{}
+	var db DB
+	t.Cleanup(func() {
+		defer db.Close()
+		var n int64 = 1
+		_ = int(n)
+		a.AssertExpectations(t)
+	})
+	a.Called()
+}
+
+func Test_NotACleanup(t *testing.T) {
+	var db DB
+	fn := func() {
+		db.Close()
+		a := &MyMock{}
+		a.AssertExpectations(t) // want "mocks must have an AssertExpectations registered in a defer or t.Cleanup"
+	}
+	fn()
+}
+
+type registerFunc func(func())
+
+func Test_NamedFuncType(t *testing.T) {
+	var register registerFunc = t.Cleanup
+	register(func() {})
+}
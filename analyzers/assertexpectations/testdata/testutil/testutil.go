@@ -0,0 +1,72 @@
+package testutil
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type Expecter interface {
+	AssertExpectations(mock.TestingT) bool
+}
+
+func RegisterMocks(t testing.TB, m1, m2 Expecter) { // want RegisterMocks:"cleanupHelper\\[1 2\\]"
+	t.Cleanup(func() {
+		m1.AssertExpectations(t)
+		m2.AssertExpectations(t)
+	})
+}
+
+func ExpectAll(t testing.TB, ms ...Expecter) { // want ExpectAll:"cleanupHelper\\[1\\]"
+	t.Cleanup(func() {
+		for _, m := range ms {
+			m.AssertExpectations(t)
+		}
+	})
+}
+
+func ExpectSlice(t testing.TB, ms []Expecter) { // want ExpectSlice:"cleanupHelper\\[1\\]"
+	t.Cleanup(func() {
+		for i := range ms {
+			ms[i].AssertExpectations(t)
+		}
+	})
+}
+
+func ExpectForObjects(t testing.TB, ms ...interface{}) { // want ExpectForObjects:"cleanupHelper\\[1\\]"
+	t.Cleanup(func() { mock.AssertExpectationsForObjects(t, ms...) })
+}
+
+func ExpectAll_Wrapped(t testing.TB, ms ...Expecter) { // want ExpectAll_Wrapped:"cleanupHelper\\[1\\]"
+	t.Log("registering mocks")
+	ExpectAll(t, ms...)
+}
+
+func Expect_Wrapped(t testing.TB, m Expecter) { // want Expect_Wrapped:"cleanupHelper\\[1\\]"
+	ExpectAll(t, m)
+}
+
+type Suite struct {
+	t testing.TB
+}
+
+func (s *Suite) Expect(m Expecter) { // want Expect:"cleanupHelper\\[0\\]"
+	s.t.Cleanup(func() { m.AssertExpectations(s.t) })
+}
+
+// Deferring AssertExpectations runs it as soon as the helper returns.
+func ExpectDeferred(t testing.TB, m Expecter) {
+	defer m.AssertExpectations(t)
+}
+
+// The registration isn't sure to happen.
+func ExpectSometimes(t testing.TB, m Expecter, expect bool) {
+	if expect {
+		t.Cleanup(func() { m.AssertExpectations(t) })
+	}
+}
+
+// The cleanup doesn't call AssertExpectations.
+func CleanupWithoutAssertExpectations(t testing.TB, m Expecter) {
+	t.Cleanup(func() { _ = m })
+}
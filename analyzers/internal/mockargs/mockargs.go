@@ -0,0 +1,234 @@
+package mockargs
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/types"
+
+	"github.com/cszczepaniak/gomockcheck/names"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+// accessorTypes maps the typed accessors of mock.Arguments to the type they assert the value to.
+var accessorTypes = map[string]types.Type{
+	"Error":  types.Universe.Lookup("error").Type(),
+	"String": types.Typ[types.String],
+	"Int":    types.Typ[types.Int],
+	"Bool":   types.Typ[types.Bool],
+}
+
+// Values describes the values held by a mock.Arguments, e.g. the parameters or results of a
+// mocked method.
+type Values struct {
+	// Method is the name of the mocked method.
+	Method string
+	// Kind is what the values are to the method, e.g. "parameter" or "result".
+	Kind string
+	// Tuple holds the types of the values.
+	Tuple *types.Tuple
+	// Allow optionally reports whether a type assertion of the i-th value to typ is valid even
+	// though typ doesn't match the value's type.
+	Allow func(typ types.Type, i int) bool
+}
+
+// Check checks the uses of mock.Arguments accessors (Get, Error, String, Int and Bool) within the
+// node against the values. Only accessors called on expressions for which isArgs returns true are
+// checked.
+func Check(pass *analysis.Pass, node ast.Node, isArgs func(ast.Expr) bool, vals Values) {
+	asserted := make(map[*ast.CallExpr]struct{})
+	ast.Inspect(node, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.TypeSwitchStmt:
+			// Each case of a type switch is an assertion of its own, e.g.
+			// switch x := ret.Get(0).(type) { case string: ... }
+			call, idx, ok := typeSwitchAccessor(pass.TypesInfo, n, isArgs)
+			if !ok {
+				return true
+			}
+
+			asserted[call] = struct{}{}
+			if !checkIndex(pass, call, "Get", idx, vals) {
+				return true
+			}
+
+			for _, stmt := range n.Body.List {
+				for _, typ := range stmt.(*ast.CaseClause).List {
+					if tv := pass.TypesInfo.Types[typ]; tv.IsType() {
+						checkAssertion(pass, typ, idx, vals)
+					}
+				}
+			}
+		case *ast.TypeAssertExpr:
+			call, ok := ast.Unparen(n.X).(*ast.CallExpr)
+			if !ok {
+				return true
+			}
+
+			if _, ok := asserted[call]; ok {
+				return true
+			}
+
+			name, idx, ok := accessor(pass.TypesInfo, call)
+			if !ok || name != "Get" || !isArgs(call.Fun.(*ast.SelectorExpr).X) {
+				return true
+			}
+
+			asserted[call] = struct{}{}
+			if !checkIndex(pass, call, name, idx, vals) || n.Type == nil {
+				return true
+			}
+
+			checkAssertion(pass, n.Type, idx, vals)
+		case *ast.CallExpr:
+			if _, ok := asserted[n]; ok {
+				return true
+			}
+
+			name, idx, ok := accessor(pass.TypesInfo, n)
+			if !ok || !isArgs(n.Fun.(*ast.SelectorExpr).X) {
+				return true
+			}
+
+			if !checkIndex(pass, n, name, idx, vals) {
+				return true
+			}
+
+			accessorTyp, ok := accessorTypes[name]
+			if !ok {
+				return true
+			}
+
+			want := vals.Tuple.At(idx).Type()
+			matches := types.Identical(want, accessorTyp)
+			if name == "Error" {
+				// Error asserts to the error interface, so any implementation of it will do.
+				matches = types.AssignableTo(want, accessorTyp)
+			}
+			if matches {
+				return true
+			}
+
+			pass.Reportf(
+				n.Pos(),
+				"%s(%d) requires %s %d of method %q to be %s, but it is %s",
+				name,
+				idx,
+				vals.Kind,
+				idx,
+				vals.Method,
+				accessorTyp,
+				want,
+			)
+		}
+		return true
+	})
+}
+
+// checkAssertion reports if the type asserted on the i-th value can't match it. The value's dynamic
+// type is assignable to the declared type, so an assertion to a concrete type only matches the
+// declared type itself or, if that's an interface, a type that implements it.
+func checkAssertion(pass *analysis.Pass, typExpr ast.Expr, i int, vals Values) {
+	want := vals.Tuple.At(i).Type()
+	got := pass.TypesInfo.TypeOf(typExpr)
+	if types.Identical(got, want) ||
+		types.IsInterface(want) && types.AssignableTo(got, want) ||
+		types.IsInterface(got) && types.AssignableTo(want, got) ||
+		vals.Allow != nil && vals.Allow(got, i) {
+		return
+	}
+
+	pass.Reportf(
+		typExpr.Pos(),
+		"type assertion to %s doesn't match %s %d of method %q, which is %s",
+		got,
+		vals.Kind,
+		i,
+		vals.Method,
+		want,
+	)
+}
+
+// typeSwitchAccessor returns the call to Get that the type switch switches on, along with its
+// index. It returns false if the switch isn't on one of the values.
+func typeSwitchAccessor(info *types.Info, stmt *ast.TypeSwitchStmt, isArgs func(ast.Expr) bool) (*ast.CallExpr, int, bool) {
+	var x ast.Expr
+	switch assign := stmt.Assign.(type) {
+	case *ast.ExprStmt:
+		x = assign.X
+	case *ast.AssignStmt:
+		x = assign.Rhs[0]
+	}
+
+	assert, ok := ast.Unparen(x).(*ast.TypeAssertExpr)
+	if !ok {
+		return nil, 0, false
+	}
+
+	call, ok := ast.Unparen(assert.X).(*ast.CallExpr)
+	if !ok {
+		return nil, 0, false
+	}
+
+	name, idx, ok := accessor(info, call)
+	if !ok || name != "Get" || !isArgs(call.Fun.(*ast.SelectorExpr).X) {
+		return nil, 0, false
+	}
+
+	return call, idx, true
+}
+
+// checkIndex reports if the index used with a mock.Arguments accessor is out of range of the
+// values. It returns whether the index is in range.
+func checkIndex(pass *analysis.Pass, call *ast.CallExpr, name string, idx int, vals Values) bool {
+	if idx < vals.Tuple.Len() {
+		return true
+	}
+
+	pass.Reportf(
+		call.Pos(),
+		"%s(%d) is out of range; method %q has %d %ss",
+		name,
+		idx,
+		vals.Method,
+		vals.Tuple.Len(),
+		vals.Kind,
+	)
+	return false
+}
+
+// accessor returns the name of the mock.Arguments method being called (e.g. Get or Error) along
+// with its constant index argument. It returns false if the call isn't to one of these methods or
+// the index isn't a constant.
+func accessor(info *types.Info, call *ast.CallExpr) (string, int, bool) {
+	fn := typeutil.StaticCallee(info, call)
+	if fn == nil || len(call.Args) != 1 {
+		return "", 0, false
+	}
+
+	recv := fn.Signature().Recv()
+	if recv == nil {
+		return "", 0, false
+	}
+
+	named, ok := recv.Type().(*types.Named)
+	if !ok || !names.IsTestifySymbol(named.Obj(), "Arguments") {
+		return "", 0, false
+	}
+
+	if _, ok := accessorTypes[fn.Name()]; !ok && fn.Name() != "Get" {
+		return "", 0, false
+	}
+
+	tv, ok := info.Types[call.Args[0]]
+	if !ok || tv.Value == nil {
+		return "", 0, false
+	}
+
+	idx, ok := constant.Int64Val(constant.ToInt(tv.Value))
+	if !ok || idx < 0 {
+		return "", 0, false
+	}
+
+	return fn.Name(), int(idx), true
+}
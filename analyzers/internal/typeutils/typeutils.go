@@ -1,6 +1,9 @@
 package typeutils
 
-import "go/types"
+import (
+	"go/types"
+	"iter"
+)
 
 func GetObjForPtrToNamedType(typ types.Type) types.Object {
 	ptr, ok := typ.(*types.Pointer)
@@ -8,10 +11,135 @@ func GetObjForPtrToNamedType(typ types.Type) types.Object {
 		return nil
 	}
 
-	named, ok := ptr.Elem().(*types.Named)
+	named, ok := types.Unalias(ptr.Elem()).(*types.Named)
 	if !ok {
 		return nil
 	}
 
 	return named.Obj()
 }
+
+// EmbeddedMockType returns the type of the mock embedded in typ, or nil if there isn't one. The mock
+// can be embedded directly or through other embedded structs (e.g. a shared base mock), and either
+// by value or by pointer. isMock reports whether an embedded type is itself a mock, e.g. testify's
+// mock.Mock.
+func EmbeddedMockType(typ types.Type, isMock func(types.Object) bool) types.Type {
+	return embeddedMockType(typ, isMock, make(map[*types.Named]struct{}))
+}
+
+func embeddedMockType(typ types.Type, isMock func(types.Object) bool, seen map[*types.Named]struct{}) types.Type {
+	s := structType(typ, seen)
+	if s == nil {
+		return nil
+	}
+
+	for i := range s.NumFields() {
+		f := s.Field(i)
+		if !f.Embedded() {
+			continue
+		}
+
+		fieldTyp := types.Unalias(f.Type())
+		named, ok := fieldTyp.(*types.Named)
+		if ptr, isPtr := fieldTyp.(*types.Pointer); isPtr {
+			named, ok = types.Unalias(ptr.Elem()).(*types.Named)
+		}
+		if !ok {
+			continue
+		}
+
+		if isMock(named.Obj()) {
+			return fieldTyp
+		}
+
+		if mockTyp := embeddedMockType(fieldTyp, isMock, seen); mockTyp != nil {
+			return mockTyp
+		}
+	}
+
+	return nil
+}
+
+// DistinctMethods returns the methods of typ that aren't methods of the mock it embeds, i.e. the
+// mocked methods. isMock reports whether an embedded type is a mock, as in EmbeddedMockType.
+func DistinctMethods(pkg *types.Package, typ types.Type, isMock func(types.Object) bool) iter.Seq[*types.Selection] {
+	mockTyp := EmbeddedMockType(typ, isMock)
+	if _, ok := mockTyp.(*types.Pointer); !ok {
+		// The mock's methods have pointer receivers, and they're promoted whether it's embedded by
+		// value or by pointer.
+		mockTyp = types.NewPointer(mockTyp)
+	}
+	mockMethodSet := types.NewMethodSet(mockTyp)
+	unexportedMockMethods := make(map[string]struct{})
+	for m := range iterMethodSet(mockMethodSet) {
+		if !m.Obj().Exported() {
+			unexportedMockMethods[m.Obj().Name()] = struct{}{}
+		}
+	}
+
+	return func(yield func(*types.Selection) bool) {
+		mSet := types.NewMethodSet(typ)
+		for method := range iterMethodSet(mSet) {
+			_, isUnexpectedMockMethod := unexportedMockMethods[method.Obj().Name()]
+			if isUnexpectedMockMethod {
+				continue
+			}
+
+			// A method declared on the mock itself can shadow one of the mock type's (e.g. when the
+			// mocked interface has an On method), in which case it's still a mocked method.
+			mockMethod := mockMethodSet.Lookup(pkg, method.Obj().Name())
+			if (mockMethod == nil || mockMethod.Obj() != method.Obj()) && !yield(method) {
+				return
+			}
+		}
+	}
+}
+
+func iterMethodSet(mSet *types.MethodSet) iter.Seq[*types.Selection] {
+	return func(yield func(*types.Selection) bool) {
+		for i := range mSet.Len() {
+			if !yield(mSet.At(i)) {
+				return
+			}
+		}
+	}
+}
+
+// structType returns the struct underlying typ, looking through pointers and aliases. It returns nil
+// if there isn't one, or if it's a named type that's already been seen; embedding can be recursive
+// through pointers.
+func structType(typ types.Type, seen map[*types.Named]struct{}) *types.Struct {
+	switch typ := types.Unalias(typ).(type) {
+	case *types.Struct:
+		return typ
+	case *types.Named:
+		if _, ok := seen[typ]; ok {
+			return nil
+		}
+		seen[typ] = struct{}{}
+		return structType(typ.Underlying(), seen)
+	case *types.Pointer:
+		return structType(typ.Elem(), seen)
+	default:
+		return nil
+	}
+}
+
+// IsReturnFunc returns whether typ is a function that computes the i-th return value of a mocked
+// method with the given signature from its arguments. Mocks generated by mockery accept either a
+// function returning the i-th result at index i, or a function returning all of the results at
+// index 0.
+func IsReturnFunc(typ types.Type, sig *types.Signature, i int) bool {
+	fn, ok := typ.Underlying().(*types.Signature)
+	if !ok {
+		return false
+	}
+
+	single := types.NewSignatureType(nil, nil, nil, sig.Params(), types.NewTuple(sig.Results().At(i)), sig.Variadic())
+	if types.Identical(fn, single) {
+		return true
+	}
+
+	all := types.NewSignatureType(nil, nil, nil, sig.Params(), sig.Results(), sig.Variadic())
+	return i == 0 && types.Identical(fn, all)
+}
@@ -0,0 +1,265 @@
+package mockimpl
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"slices"
+
+	"github.com/cszczepaniak/gomockcheck/analyzers/internal/typeutils"
+	"github.com/cszczepaniak/gomockcheck/names"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+func New(typs ...names.QualifiedType) *analysis.Analyzer {
+	r := &runner{
+		types: slices.Concat([]names.QualifiedType{{
+			PkgPath: "github.com/stretchr/testify/mock",
+			Name:    "Mock",
+		}}, typs),
+	}
+
+	return &analysis.Analyzer{
+		Name:     "mockimpl",
+		Doc:      "Checks that mock types and methods record their calls correctly",
+		Run:      r.run,
+		Requires: []*analysis.Analyzer{inspect.Analyzer},
+	}
+}
+
+type runner struct {
+	types []names.QualifiedType
+}
+
+func (r *runner) run(pass *analysis.Pass) (any, error) {
+	r.checkMockTypes(pass)
+
+	inspector := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	inspector.Preorder(
+		[]ast.Node{&ast.FuncDecl{}},
+		func(n ast.Node) {
+			decl := n.(*ast.FuncDecl)
+			if decl.Recv == nil || decl.Body == nil {
+				return
+			}
+
+			fn, ok := pass.TypesInfo.Defs[decl.Name].(*types.Func)
+			if !ok || typeutils.EmbeddedMockType(fn.Signature().Recv().Type(), r.isMockObj) == nil {
+				return
+			}
+
+			r.checkMockMethod(pass, decl, fn)
+		},
+	)
+
+	return nil, nil
+}
+
+func (r *runner) checkMockMethod(pass *analysis.Pass, decl *ast.FuncDecl, fn *types.Func) {
+	var calls []*ast.CallExpr
+	ast.Inspect(decl.Body, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if ok && r.isMockFunc(pass.TypesInfo, call, "Called", "MethodCalled") {
+			calls = append(calls, call)
+		}
+		return true
+	})
+
+	// Methods that never record their calls aren't necessarily mocked methods (they could be
+	// helpers, for example), so there's nothing to check here.
+	if len(calls) == 0 {
+		return
+	}
+
+	for _, call := range calls[1:] {
+		pass.Reportf(call.Pos(), "mocked method %q should record its call exactly once", fn.Name())
+	}
+
+	checkCalledArgs(pass, decl, calls[0], fn)
+	checkResults(pass, decl, fn, calls)
+}
+
+// checkCalledArgs makes sure that the call to Called or MethodCalled forwards every parameter of
+// the mocked method in order. Mock setups are checked against the method's signature, so if the
+// recorded arguments differ from the parameters, a setup that looks valid can never match.
+func checkCalledArgs(pass *analysis.Pass, decl *ast.FuncDecl, call *ast.CallExpr, fn *types.Func) {
+	calledName := call.Fun.(*ast.SelectorExpr).Sel.Name
+	args := call.Args
+	if calledName == "MethodCalled" {
+		if len(args) == 0 {
+			return
+		}
+		// The first argument to MethodCalled is the name of the method.
+		args = args[1:]
+	}
+
+	sig := fn.Signature()
+	if unrolled, ok := unrolledArgs(pass.TypesInfo, decl, call); ok && sig.Variadic() {
+		args = unrolled
+	} else if call.Ellipsis.IsValid() {
+		// Mock setups (and mocksetup) expect a variadic parameter to be recorded as a single slice,
+		// so spreading the arguments into the call will record them differently than expected.
+		// Otherwise, we can't tell what's in the slice being spread.
+		if sig.Variadic() {
+			pass.Reportf(
+				call.Pos(),
+				"variadic parameter %q of method %q should be passed to %s as a single slice, not spread",
+				sig.Params().At(sig.Params().Len()-1).Name(),
+				fn.Name(),
+				calledName,
+			)
+		}
+		return
+	}
+
+	if len(args) != sig.Params().Len() {
+		pass.Reportf(
+			call.Pos(),
+			"%s records %d arguments, but method %q takes %d",
+			calledName,
+			len(args),
+			fn.Name(),
+			sig.Params().Len(),
+		)
+		return
+	}
+
+	for i, arg := range args {
+		param := sig.Params().At(i)
+		if param.Name() == "" || param.Name() == "_" {
+			// There's no way to refer to this parameter, so we can't expect it to be forwarded.
+			continue
+		}
+
+		id, ok := ast.Unparen(arg).(*ast.Ident)
+		if ok && pass.TypesInfo.Uses[id] == param {
+			continue
+		}
+
+		pass.Reportf(arg.Pos(), "argument %d to %s should be parameter %q", i, calledName, param.Name())
+	}
+}
+
+// unrolledArgs returns the arguments that are spread into the call to Called the way mockery does
+// for variadic methods, e.g.
+//
+//	_va := make([]interface{}, len(ids))
+//	for _i := range ids {
+//		_va[_i] = ids[_i]
+//	}
+//	var _ca []interface{}
+//	_ca = append(_ca, ctx)
+//	_ca = append(_ca, _va...)
+//	_m.Called(_ca...)
+//
+// The variadic arguments are recorded one by one, which is how mockery's expecters set them up.
+// The slice they're copied from (_va) stands for the variadic parameter, which is returned in its
+// place. It returns false if the call doesn't spread a slice that's built like that.
+func unrolledArgs(info *types.Info, decl *ast.FuncDecl, call *ast.CallExpr) ([]ast.Expr, bool) {
+	if !call.Ellipsis.IsValid() || len(call.Args) == 0 {
+		return nil, false
+	}
+
+	spread, ok := ast.Unparen(call.Args[len(call.Args)-1]).(*ast.Ident)
+	if !ok {
+		return nil, false
+	}
+	slice := info.Uses[spread]
+
+	// The slices that the variadic arguments are copied to, e.g. _va := make([]interface{}, len(ids)),
+	// mapped to the variadic parameter.
+	copies := make(map[types.Object]ast.Expr)
+
+	var args []ast.Expr
+	ok = true
+	ast.Inspect(decl.Body, func(n ast.Node) bool {
+		assign, isAssign := n.(*ast.AssignStmt)
+		if !isAssign || len(assign.Lhs) != 1 || len(assign.Rhs) != 1 || !ok {
+			return ok
+		}
+
+		lhs, isIdent := assign.Lhs[0].(*ast.Ident)
+		rhs, isCall := assign.Rhs[0].(*ast.CallExpr)
+		if !isIdent || !isCall {
+			return true
+		}
+
+		if assign.Tok == token.DEFINE {
+			if param, isCopy := variadicCopy(info, rhs); isCopy {
+				copies[info.Defs[lhs]] = param
+			}
+			return true
+		}
+
+		if info.Uses[lhs] != slice {
+			return true
+		}
+
+		// _ca = append(_ca, ...)
+		if b, isBuiltin := info.Uses[calleeIdent(rhs)].(*types.Builtin); !isBuiltin || b.Name() != "append" {
+			ok = false
+			return false
+		}
+		if !rhs.Ellipsis.IsValid() {
+			args = append(args, rhs.Args[1:]...)
+			return true
+		}
+
+		id, isIdent := ast.Unparen(rhs.Args[len(rhs.Args)-1]).(*ast.Ident)
+		param, isCopy := copies[info.Uses[id]]
+		if !isIdent || !isCopy {
+			ok = false
+			return false
+		}
+		args = append(args, rhs.Args[1:len(rhs.Args)-1]...)
+		args = append(args, param)
+		return true
+	})
+
+	return args, ok && len(args) > 0
+}
+
+// variadicCopy returns the parameter in a call like make([]interface{}, len(ids)), which makes the
+// slice that mockery copies the variadic arguments to.
+func variadicCopy(info *types.Info, call *ast.CallExpr) (ast.Expr, bool) {
+	if b, ok := info.Uses[calleeIdent(call)].(*types.Builtin); !ok || b.Name() != "make" || len(call.Args) != 2 {
+		return nil, false
+	}
+
+	length, ok := ast.Unparen(call.Args[1]).(*ast.CallExpr)
+	if !ok || len(length.Args) != 1 {
+		return nil, false
+	}
+	if b, ok := info.Uses[calleeIdent(length)].(*types.Builtin); !ok || b.Name() != "len" {
+		return nil, false
+	}
+
+	return length.Args[0], true
+}
+
+func calleeIdent(call *ast.CallExpr) *ast.Ident {
+	id, _ := ast.Unparen(call.Fun).(*ast.Ident)
+	return id
+}
+
+func (r *runner) isMockFunc(info *types.Info, call *ast.CallExpr, oneOf ...string) bool {
+	fn := typeutil.StaticCallee(info, call)
+	if fn == nil || !slices.Contains(oneOf, fn.Name()) {
+		return false
+	}
+
+	recv := fn.Signature().Recv()
+	if recv == nil {
+		return false
+	}
+
+	obj := typeutils.GetObjForPtrToNamedType(recv.Type())
+	return obj != nil && names.IsOneOf(obj, r.types...)
+}
+
+func (r *runner) isMockObj(obj types.Object) bool {
+	return names.IsOneOf(obj, r.types...)
+}
@@ -0,0 +1,15 @@
+package mockimpl
+
+import (
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestMockImpl(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), New(), "./...")
+}
+
+func TestMockImpl_SuggestedFixes(t *testing.T) {
+	analysistest.RunWithSuggestedFixes(t, analysistest.TestData(), New(), "./suggestedfixes")
+}
@@ -0,0 +1,323 @@
+package mockimpl
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strings"
+
+	"github.com/cszczepaniak/gomockcheck/analyzers/internal/typeutils"
+	"golang.org/x/tools/go/analysis"
+)
+
+// checkMockTypes checks the declarations of mock types for structures that break the mock at
+// runtime:
+//   - A mock embedded by pointer is nil unless it's initialized, so setting up the mock panics.
+//   - Methods with value receivers that record their calls record them on a copy of the mock, so
+//     the calls are lost and AssertExpectations can't see them.
+//   - Methods with the same names as the mock's (e.g. On) shadow them, so they can't be called as
+//     usual.
+func (r *runner) checkMockTypes(pass *analysis.Pass) {
+	valueRecvs := r.valueReceivers(pass)
+
+	for _, file := range pass.Files {
+		for _, decl := range file.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.TYPE {
+				continue
+			}
+
+			for _, spec := range gen.Specs {
+				r.checkMockType(pass, spec.(*ast.TypeSpec), valueRecvs)
+			}
+		}
+	}
+}
+
+func (r *runner) checkMockType(pass *analysis.Pass, spec *ast.TypeSpec, valueRecvs map[*types.TypeName][]*ast.FuncDecl) {
+	st, ok := spec.Type.(*ast.StructType)
+	if !ok || spec.Assign.IsValid() {
+		return
+	}
+
+	obj, ok := pass.TypesInfo.Defs[spec.Name].(*types.TypeName)
+	if !ok {
+		return
+	}
+
+	mockTyp := typeutils.EmbeddedMockType(obj.Type(), r.isMockObj)
+	if mockTyp == nil {
+		return
+	}
+
+	checkShadowedMethods(pass, spec, obj, mockTyp)
+
+	// Converting the receivers to pointers isn't necessary while the mock is embedded by pointer,
+	// but it will be once it's embedded by value.
+	recvEdits := make([]analysis.TextEdit, 0, len(valueRecvs[obj]))
+	for _, decl := range valueRecvs[obj] {
+		recvEdits = append(recvEdits, analysis.TextEdit{
+			Pos:     decl.Recv.List[0].Type.Pos(),
+			End:     decl.Recv.List[0].Type.Pos(),
+			NewText: []byte("*"),
+		})
+	}
+
+	embedsPointer := false
+	for _, field := range st.Fields.List {
+		star, ok := field.Type.(*ast.StarExpr)
+		if len(field.Names) > 0 || !ok || !r.isMockType(pass.TypesInfo.TypeOf(star.X)) {
+			continue
+		}
+
+		embedsPointer = true
+		diag := analysis.Diagnostic{
+			Pos: field.Pos(),
+			End: field.End(),
+			Message: fmt.Sprintf(
+				"mock %s embeds %s by pointer, so it panics unless the pointer is initialized; embed it by value",
+				obj.Name(),
+				types.ExprString(field.Type),
+			),
+		}
+		if fieldEdits, ok := embeddedFieldEdits(pass, obj, field); ok {
+			edits := append([]analysis.TextEdit{{
+				Pos: star.Star,
+				End: star.X.Pos(),
+			}}, fieldEdits...)
+
+			diag.SuggestedFixes = []analysis.SuggestedFix{{
+				Message:   "embed by value",
+				TextEdits: append(edits, recvEdits...),
+			}}
+		}
+		pass.Report(diag)
+	}
+
+	if embedsPointer || len(recvEdits) == 0 {
+		return
+	}
+
+	methods := make([]string, 0, len(valueRecvs[obj]))
+	for _, decl := range valueRecvs[obj] {
+		methods = append(methods, decl.Name.Name)
+	}
+
+	pass.Report(analysis.Diagnostic{
+		Pos: spec.Name.Pos(),
+		End: spec.Name.End(),
+		Message: fmt.Sprintf(
+			"mock %s has methods with value receivers (%s), so they record calls on a copy of the mock; use pointer receivers",
+			obj.Name(),
+			strings.Join(methods, ", "),
+		),
+		SuggestedFixes: []analysis.SuggestedFix{{
+			Message:   "use pointer receivers",
+			TextEdits: recvEdits,
+		}},
+	})
+}
+
+// embeddedFieldEdits returns edits that update the uses of the field embedded by pointer in the
+// package once it's embedded by value. Composite literals that initialize it with &T{...} are
+// changed to use T{...}, and selections of the field are fine as long as they're only used to
+// select something from it. It returns false if there's a use that can't be updated, e.g. a
+// pointer that's shared with something else.
+func embeddedFieldEdits(pass *analysis.Pass, obj *types.TypeName, field *ast.Field) ([]analysis.TextEdit, bool) {
+	st, ok := obj.Type().Underlying().(*types.Struct)
+	if !ok {
+		return nil, false
+	}
+
+	idx := -1
+	for i := range st.NumFields() {
+		if f := st.Field(i); f.Embedded() && f.Pos() >= field.Pos() && f.Pos() < field.End() {
+			idx = i
+		}
+	}
+	if idx < 0 {
+		return nil, false
+	}
+	isField := func(v types.Object) bool {
+		fv, ok := v.(*types.Var)
+		return ok && fv.Origin() == st.Field(idx)
+	}
+
+	var edits []analysis.TextEdit
+	ok = true
+	for _, file := range pass.Files {
+		// The selections of the field that something else is selected from.
+		selectedFrom := make(map[ast.Expr]bool)
+		ast.Inspect(file, func(n ast.Node) bool {
+			if sel, isSel := n.(*ast.SelectorExpr); isSel {
+				selectedFrom[ast.Unparen(sel.X)] = true
+			}
+			return true
+		})
+
+		ast.Inspect(file, func(n ast.Node) bool {
+			switch n := n.(type) {
+			case *ast.CompositeLit:
+				for i, elt := range n.Elts {
+					val := elt
+					if kv, isKV := elt.(*ast.KeyValueExpr); isKV {
+						key, isIdent := kv.Key.(*ast.Ident)
+						if !isIdent || !isField(pass.TypesInfo.Uses[key]) {
+							continue
+						}
+						val = kv.Value
+					} else if i != idx || !isStructOf(pass.TypesInfo.TypeOf(n), obj) {
+						continue
+					}
+
+					unary, isUnary := ast.Unparen(val).(*ast.UnaryExpr)
+					if !isUnary || unary.Op != token.AND {
+						ok = false
+						return false
+					}
+					if _, isLit := ast.Unparen(unary.X).(*ast.CompositeLit); !isLit {
+						ok = false
+						return false
+					}
+					edits = append(edits, analysis.TextEdit{Pos: unary.OpPos, End: unary.X.Pos()})
+				}
+
+			case *ast.SelectorExpr:
+				if s := pass.TypesInfo.Selections[n]; s != nil && isField(s.Obj()) && !selectedFrom[n] {
+					ok = false
+				}
+			}
+			return ok
+		})
+	}
+
+	return edits, ok
+}
+
+// isStructOf returns whether the type is the struct type declared by obj, or an instance of it.
+func isStructOf(typ types.Type, obj *types.TypeName) bool {
+	named, ok := types.Unalias(typ).(*types.Named)
+	return ok && named.Origin().Obj() == obj
+}
+
+// checkShadowedMethods reports the methods of a mock type that shadow methods of the mock it
+// embeds, which happens when the mocked interface has methods like On or Called. Calling one of the
+// shadowed methods on the mock calls the mocked method instead, so they have to be called on the
+// embedded mock explicitly.
+func checkShadowedMethods(pass *analysis.Pass, spec *ast.TypeSpec, obj *types.TypeName, mockTyp types.Type) {
+	named, ok := obj.Type().(*types.Named)
+	if !ok {
+		return
+	}
+
+	mockPtr, ok := mockTyp.(*types.Pointer)
+	if !ok {
+		mockPtr = types.NewPointer(mockTyp)
+	}
+	mockNamed, ok := types.Unalias(mockPtr.Elem()).(*types.Named)
+	if !ok {
+		return
+	}
+
+	var shadowed []string
+	for i := range named.NumMethods() {
+		m := named.Method(i)
+		if mockMethod, _, _ := types.LookupFieldOrMethod(mockPtr, false, m.Pkg(), m.Name()); mockMethod != nil {
+			shadowed = append(shadowed, m.Name())
+		}
+	}
+
+	if len(shadowed) == 0 {
+		return
+	}
+
+	pass.Reportf(
+		spec.Name.Pos(),
+		"methods of mock %s shadow methods of %s (%s); set up and record calls through the embedded mock instead, e.g. m.%s.On(...)",
+		obj.Name(),
+		types.TypeString(mockNamed, func(p *types.Package) string { return p.Name() }),
+		strings.Join(shadowed, ", "),
+		mockNamed.Obj().Name(),
+	)
+}
+
+// isMockType returns whether the type is a mock, or embeds one.
+func (r *runner) isMockType(typ types.Type) bool {
+	named, ok := types.Unalias(typ).(*types.Named)
+	if ok && r.isMockObj(named.Obj()) {
+		return true
+	}
+
+	return typeutils.EmbeddedMockType(typ, r.isMockObj) != nil
+}
+
+// valueReceivers returns the declarations of the methods in the package that have value receivers
+// and record their calls on the receiver's mock, grouped by the type they're declared on. Other
+// methods, like a String method, don't touch the mock, so copying it doesn't matter.
+func (r *runner) valueReceivers(pass *analysis.Pass) map[*types.TypeName][]*ast.FuncDecl {
+	decls := make(map[*types.TypeName][]*ast.FuncDecl)
+	for _, file := range pass.Files {
+		for _, decl := range file.Decls {
+			fd, ok := decl.(*ast.FuncDecl)
+			if !ok || fd.Recv == nil || !r.recordsCalls(pass.TypesInfo, fd) {
+				continue
+			}
+
+			fn, ok := pass.TypesInfo.Defs[fd.Name].(*types.Func)
+			if !ok {
+				continue
+			}
+
+			named, ok := types.Unalias(fn.Signature().Recv().Type()).(*types.Named)
+			if ok {
+				decls[named.Obj()] = append(decls[named.Obj()], fd)
+			}
+		}
+	}
+
+	return decls
+}
+
+// recordsCalls returns whether the method calls Called or MethodCalled on the mock embedded in its
+// receiver.
+func (r *runner) recordsCalls(info *types.Info, decl *ast.FuncDecl) bool {
+	if len(decl.Recv.List[0].Names) == 0 {
+		return false
+	}
+	recv := info.Defs[decl.Recv.List[0].Names[0]]
+
+	found := false
+	ast.Inspect(decl.Body, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok || !r.isMockFunc(info, call, "Called", "MethodCalled") {
+			return !found
+		}
+
+		sel, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+
+		// Look through explicit selections of embedded fields, e.g. m.Mock.Called.
+		x := ast.Unparen(sel.X)
+		for {
+			xSel, ok := x.(*ast.SelectorExpr)
+			if !ok {
+				break
+			}
+			s := info.Selections[xSel]
+			if s == nil || s.Kind() != types.FieldVal || !s.Obj().(*types.Var).Embedded() {
+				break
+			}
+			x = ast.Unparen(xSel.X)
+		}
+
+		if id, ok := x.(*ast.Ident); ok && recv != nil && info.Uses[id] == recv {
+			found = true
+		}
+		return !found
+	})
+
+	return found
+}
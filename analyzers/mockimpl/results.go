@@ -0,0 +1,57 @@
+package mockimpl
+
+import (
+	"go/ast"
+	"go/types"
+	"slices"
+
+	"github.com/cszczepaniak/gomockcheck/analyzers/internal/mockargs"
+	"github.com/cszczepaniak/gomockcheck/analyzers/internal/typeutils"
+	"golang.org/x/tools/go/analysis"
+)
+
+// checkResults checks the uses of the mock.Arguments returned from the given calls to Called
+// against the results of the mocked method. Each of these values is set up by a call to Return, so
+// they must line up with the results of the method; mocksetup makes sure the calls to Return do.
+func checkResults(pass *analysis.Pass, decl *ast.FuncDecl, fn *types.Func, calls []*ast.CallExpr) {
+	isCalled := func(expr ast.Expr) bool {
+		call, ok := ast.Unparen(expr).(*ast.CallExpr)
+		return ok && slices.Contains(calls, call)
+	}
+
+	// Find the variables that the recorded arguments are assigned to, e.g. ret := m.Called().
+	argVars := make(map[types.Object]struct{})
+	ast.Inspect(decl.Body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.AssignStmt:
+			if len(n.Lhs) == 1 && len(n.Rhs) == 1 && isCalled(n.Rhs[0]) {
+				if id, ok := n.Lhs[0].(*ast.Ident); ok {
+					argVars[pass.TypesInfo.ObjectOf(id)] = struct{}{}
+				}
+			}
+		case *ast.ValueSpec:
+			if len(n.Names) == 1 && len(n.Values) == 1 && isCalled(n.Values[0]) {
+				argVars[pass.TypesInfo.ObjectOf(n.Names[0])] = struct{}{}
+			}
+		}
+		return true
+	})
+
+	isRecordedArgs := func(expr ast.Expr) bool {
+		if id, ok := ast.Unparen(expr).(*ast.Ident); ok {
+			_, ok := argVars[pass.TypesInfo.Uses[id]]
+			return ok
+		}
+		return isCalled(expr)
+	}
+
+	sig := fn.Signature()
+	mockargs.Check(pass, decl.Body, isRecordedArgs, mockargs.Values{
+		Method: fn.Name(),
+		Kind:   "result",
+		Tuple:  sig.Results(),
+		Allow: func(typ types.Type, i int) bool {
+			return typeutils.IsReturnFunc(typ, sig, i)
+		},
+	})
+}
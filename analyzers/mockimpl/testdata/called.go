@@ -0,0 +1,103 @@
+package testdata
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MyMock struct {
+	mock.Mock
+}
+
+func (m *MyMock) NoArgs() {
+	m.Called()
+}
+
+func (m *MyMock) Forwarded(ctx context.Context, id string, n int) error {
+	return m.Called(ctx, id, n).Error(0)
+}
+
+func (m *MyMock) ForwardedThroughField(ctx context.Context, id string) {
+	m.Mock.Called(ctx, id)
+}
+
+func (m *MyMock) ForwardedToMethodCalled(ctx context.Context, id string) {
+	m.MethodCalled("ForwardedToMethodCalled", ctx, id)
+}
+
+func (m *MyMock) Variadic(id string, opts ...int) {
+	m.Called(id, opts)
+}
+
+func (m *MyMock) UnnamedParams(context.Context, string) {
+	m.Called(nil, nil)
+}
+
+func (m *MyMock) BlankParam(_ context.Context, id string) {
+	m.Called(nil, id)
+}
+
+func (m *MyMock) Helper() *MyMock {
+	// This doesn't record a call, so it isn't a mocked method.
+	return m
+}
+
+func (m *MyMock) MissingArg(ctx context.Context, id string, n int) {
+	m.Called(ctx, id) // want `Called records 2 arguments, but method "MissingArg" takes 3`
+}
+
+func (m *MyMock) ExtraArg(ctx context.Context) {
+	m.MethodCalled("ExtraArg", ctx, 1) // want `MethodCalled records 2 arguments, but method "ExtraArg" takes 1`
+}
+
+func (m *MyMock) WrongOrder(ctx context.Context, a, b string) {
+	m.Called(
+		ctx,
+		b, // want `argument 1 to Called should be parameter "a"`
+		a, // want `argument 2 to Called should be parameter "b"`
+	)
+}
+
+func (m *MyMock) NotAParam(ctx context.Context, id string) {
+	m.Called(
+		context.Background(), // want `argument 0 to Called should be parameter "ctx"`
+		id,
+	)
+}
+
+func (m *MyMock) CalledTwice(id string) {
+	m.Called(id)
+	m.Called(id) // want `mocked method "CalledTwice" should record its call exactly once`
+}
+
+func (m *MyMock) VariadicSpread(id string, opts ...any) {
+	args := append([]any{id}, opts...)
+	m.Called(args...) // want `variadic parameter "opts" of method "VariadicSpread" should be passed to Called as a single slice, not spread`
+}
+
+func (m *MyMock) VariadicSpread_MockeryStyle(ctx context.Context, opts ...int) {
+	// This is how mockery unrolls variadic arguments.
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, _va...)
+	m.Called(_ca...)
+}
+
+func (m *MyMock) NonVariadicSpread(id string) {
+	// We can't tell what's in the slice here.
+	args := []any{id}
+	m.Called(args...)
+}
+
+type NotAMock struct{}
+
+func (n *NotAMock) Called(args ...any) {}
+
+func (n *NotAMock) Method(a, b string) {
+	n.Called(b)
+}
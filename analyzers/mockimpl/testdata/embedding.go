@@ -0,0 +1,33 @@
+package testdata
+
+import "github.com/stretchr/testify/mock"
+
+type BaseMock struct {
+	mock.Mock
+}
+
+type EmbedsBaseMock struct {
+	BaseMock
+}
+
+func (m *EmbedsBaseMock) Get(id string) error {
+	return m.Called().Error(0) // want `Called records 0 arguments, but method "Get" takes 1`
+}
+
+type EmbedsMockPointer struct {
+	*mock.Mock // want `mock EmbedsMockPointer embeds \*mock.Mock by pointer, so it panics unless the pointer is initialized; embed it by value`
+}
+
+func (m *EmbedsMockPointer) Get(id string) error {
+	return m.Called().Error(0) // want `Called records 0 arguments, but method "Get" takes 1`
+}
+
+type testifyMock = mock.Mock
+
+type EmbedsAliasedMock struct {
+	testifyMock
+}
+
+func (m *EmbedsAliasedMock) Get(id string) error {
+	return m.Called().Error(0) // want `Called records 0 arguments, but method "Get" takes 1`
+}
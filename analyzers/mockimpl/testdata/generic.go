@@ -0,0 +1,22 @@
+package testdata
+
+import "github.com/stretchr/testify/mock"
+
+type GenericMock[K comparable, V any] struct {
+	mock.Mock
+}
+
+func (m *GenericMock[K, V]) Load(key K) (V, error) {
+	ret := m.Called(key)
+	return ret.Get(0).(V), ret.Error(1)
+}
+
+func (m *GenericMock[K, V]) Store(key K, val V) {
+	m.Called(val, key) // want `argument 0 to Called should be parameter "key"` `argument 1 to Called should be parameter "val"`
+}
+
+func (m *GenericMock[K, V]) Keys() []K {
+	ret := m.Called()
+	_ = ret.Get(0).([]V) // want `type assertion to \[\]V doesn't match result 0 of method "Keys", which is \[\]K`
+	return ret.Get(0).([]K)
+}
@@ -0,0 +1,128 @@
+package testdata
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type myErr struct{}
+
+func (myErr) Error() string { return "" }
+
+type ResultsMock struct {
+	mock.Mock
+}
+
+func (m *ResultsMock) Correct(id string) (*os.File, int, string, bool, error) {
+	ret := m.Called(id)
+	return ret.Get(0).(*os.File), ret.Int(1), ret.String(2), ret.Bool(3), ret.Error(4)
+}
+
+func (m *ResultsMock) CorrectInline(id string) error {
+	return m.Called(id).Error(0)
+}
+
+func (m *ResultsMock) CustomError() *myErr {
+	var args = m.Called()
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Error(0).(*myErr)
+}
+
+func (m *ResultsMock) OutOfRange(id string) error {
+	ret := m.Called(id)
+	_ = ret.Get(1)      // want `Get\(1\) is out of range; method "OutOfRange" has 1 results`
+	return ret.Error(1) // want `Error\(1\) is out of range; method "OutOfRange" has 1 results`
+}
+
+func (m *ResultsMock) NoResults(id string) {
+	ret := m.Called(id)
+	_ = ret.Get(0).(string) // want `Get\(0\) is out of range; method "NoResults" has 0 results`
+}
+
+func (m *ResultsMock) WrongAccessors(id string) (string, error) {
+	ret := m.Called(id)
+	_ = ret.Int(0)                     // want `Int\(0\) requires result 0 of method "WrongAccessors" to be int, but it is string`
+	_ = ret.Bool(1)                    // want `Bool\(1\) requires result 1 of method "WrongAccessors" to be bool, but it is error`
+	return ret.String(0), ret.Error(0) // want `Error\(0\) requires result 0 of method "WrongAccessors" to be error, but it is string`
+}
+
+type myString string
+
+func (m *ResultsMock) NamedString() myString {
+	ret := m.Called()
+	return myString(ret.String(0)) // want `String\(0\) requires result 0 of method "NamedString" to be string, but it is example.com.myString`
+}
+
+func (m *ResultsMock) WrongAssertion(id string) (int64, io.Reader) {
+	ret := m.Called(id)
+	a := ret.Get(0).(int) // want `type assertion to int doesn't match result 0 of method "WrongAssertion", which is int64`
+	b := ret.Get(1).(*os.File)
+	_ = ret.Get(1).(io.ReadCloser)
+	_ = ret.Get(1).(string) // want `type assertion to string doesn't match result 1 of method "WrongAssertion", which is io.Reader`
+	return int64(a), b
+}
+
+func (m *ResultsMock) Mockery(id string, n int) (int64, error) {
+	ret := m.Called(id, n)
+
+	if rf, ok := ret.Get(0).(func(string, int) (int64, error)); ok {
+		return rf(id, n)
+	}
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(string, int) int64); ok {
+		r0 = rf(id, n)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, int) error); ok {
+		r1 = rf(id, n)
+	} else if rf, ok := ret.Get(1).(func(string) error); ok { // want `type assertion to func\(string\) error doesn't match result 1 of method "Mockery", which is error`
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+func (m *ResultsMock) AssignableAssertion() ([]byte, fmt.Stringer) {
+	ret := m.Called()
+	// The value is a []byte, so asserting it to another type with the same underlying type fails.
+	_ = ret.Get(0).(json.RawMessage) // want `type assertion to encoding/json.RawMessage doesn't match result 0 of method "AssignableAssertion", which is \[\]byte`
+	return ret.Get(0).([]byte), ret.Get(1).(fmt.Stringer)
+}
+
+func (m *ResultsMock) TypeSwitch() error {
+	ret := m.Called()
+	switch err := ret.Get(0).(type) {
+	case nil:
+		return nil
+	case myErr, *os.PathError:
+		return errors.New("")
+	case string: // want `type assertion to string doesn't match result 0 of method "TypeSwitch", which is error`
+		return errors.New(err)
+	}
+
+	switch ret.Get(1).(type) { // want `Get\(1\) is out of range; method "TypeSwitch" has 1 results`
+	case int:
+	}
+	return nil
+}
+
+func (m *ResultsMock) OtherArguments() int {
+	m.Called()
+
+	// These aren't the recorded arguments, so we can't check them.
+	args := mock.Arguments{"foo"}
+	return args.Int(5)
+}
@@ -0,0 +1,19 @@
+package testdata
+
+import "github.com/stretchr/testify/mock"
+
+type ShadowingMock struct { // want `methods of mock ShadowingMock shadow methods of mock.Mock \(On, Test\); set up and record calls through the embedded mock instead, e.g. m.Mock.On\(...\)`
+	mock.Mock
+}
+
+func (m *ShadowingMock) On(event string, handler func()) error {
+	return m.Mock.Called(event, handler).Error(0)
+}
+
+func (m *ShadowingMock) Test(name string) bool {
+	return m.Mock.Called().Bool(0) // want `Called records 0 arguments, but method "Test" takes 1`
+}
+
+func (m *ShadowingMock) Get(id string) error {
+	return m.Called(id).Error(0)
+}
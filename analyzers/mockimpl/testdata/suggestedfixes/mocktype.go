@@ -0,0 +1,111 @@
+package suggestedfixes
+
+import "github.com/stretchr/testify/mock"
+
+type PointerMock struct {
+	*mock.Mock // want `mock PointerMock embeds \*mock.Mock by pointer, so it panics unless the pointer is initialized; embed it by value`
+}
+
+func (m *PointerMock) Get(id string) error {
+	return m.Called(id).Error(0)
+}
+
+func newPointerMock() *PointerMock {
+	return &PointerMock{Mock: &mock.Mock{}}
+}
+
+func (m *PointerMock) Expect(id string) {
+	m.Mock.On("Get", id).Return(nil)
+}
+
+type ValueReceiverMock struct { // want `mock ValueReceiverMock has methods with value receivers \(Get, Put\), so they record calls on a copy of the mock; use pointer receivers`
+	mock.Mock
+}
+
+func (m ValueReceiverMock) Get(id string) error {
+	return m.Called(id).Error(0)
+}
+
+func (m ValueReceiverMock) Put(id string) {
+	m.Called(id)
+}
+
+func (m *ValueReceiverMock) Delete(id string) {
+	m.Called(id)
+}
+
+// Describe doesn't record a call, so it can keep its value receiver.
+func (m ValueReceiverMock) Describe() string {
+	return "ValueReceiverMock"
+}
+
+type HelperMock struct {
+	mock.Mock
+	inner *ValueReceiverMock
+}
+
+func (m *HelperMock) Get(id string) error {
+	return m.Mock.Called(id).Error(0)
+}
+
+// These don't record calls on HelperMock's mock, so copying it doesn't matter.
+func (m HelperMock) Name() string {
+	return "helper"
+}
+
+func (m HelperMock) Put(id string) {
+	m.inner.Called(id)
+}
+
+type BothMock struct {
+	*mock.Mock // want `mock BothMock embeds \*mock.Mock by pointer, so it panics unless the pointer is initialized; embed it by value`
+}
+
+func (m BothMock) Get(id string) error {
+	return m.Called(id).Error(0)
+}
+
+func newBothMock() *BothMock {
+	return &BothMock{&mock.Mock{}}
+}
+
+type SharedMock struct {
+	*mock.Mock // want `mock SharedMock embeds \*mock.Mock by pointer, so it panics unless the pointer is initialized; embed it by value`
+}
+
+func newSharedMock(m *mock.Mock) *SharedMock {
+	return &SharedMock{Mock: m}
+}
+
+type ExposedMock struct {
+	*mock.Mock // want `mock ExposedMock embeds \*mock.Mock by pointer, so it panics unless the pointer is initialized; embed it by value`
+}
+
+func (m *ExposedMock) Inner() *mock.Mock {
+	return m.Mock
+}
+
+type BaseMock struct {
+	mock.Mock
+}
+
+type PointerBaseMock struct {
+	*BaseMock // want `mock PointerBaseMock embeds \*BaseMock by pointer, so it panics unless the pointer is initialized; embed it by value`
+}
+
+type GenericMock[T any] struct { // want `mock GenericMock has methods with value receivers \(Get\), so they record calls on a copy of the mock; use pointer receivers`
+	mock.Mock
+}
+
+func (m GenericMock[T]) Get(id string) T {
+	return m.Called(id).Get(0).(T)
+}
+
+type NotAMock struct {
+	*NotAMock
+	Name string
+}
+
+func (n NotAMock) String() string {
+	return n.Name
+}
@@ -0,0 +1,316 @@
+package mocksetup
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// checkAnythingOfTypeString checks that the type named by a mock.AnythingOfType on an interface
+// parameter could match an argument. testify compares the string to the reflect.Type of the
+// argument (both its Name and its String), so the string has to name a concrete type that
+// implements the interface, formatted the way reflect formats types: qualified by package name
+// rather than by import path or import alias. If the string is valid and refers to exactly one
+// type, that type is returned.
+func checkAnythingOfTypeString(pass *analysis.Pass, iface types.Type, call *ast.CallExpr) types.Type {
+	if len(call.Args) != 1 {
+		return nil
+	}
+
+	tv, ok := pass.TypesInfo.Types[call.Args[0]]
+	if !ok || tv.Value == nil || tv.Value.Kind() != constant.String {
+		return nil
+	}
+
+	typStr := constant.StringVal(tv.Value)
+	res := newTypeStringResolver(pass)
+	typs, canonical, ok := res.resolve(typStr)
+	if !ok {
+		// This is a type string we don't understand, so we can't say anything about it.
+		return nil
+	}
+
+	if len(typs) == 0 {
+		pass.Reportf(call.Args[0].Pos(), "type %q in mock.AnythingOfType doesn't exist", typStr)
+		return nil
+	}
+
+	if canonical != typStr {
+		var suggestedFixes []analysis.SuggestedFix
+		if lit, ok := ast.Unparen(call.Args[0]).(*ast.BasicLit); ok && lit.Kind == token.STRING {
+			suggestedFixes = []analysis.SuggestedFix{{
+				Message: "replace with " + strconv.Quote(canonical),
+				TextEdits: []analysis.TextEdit{{
+					Pos:     lit.Pos(),
+					End:     lit.End(),
+					NewText: []byte(strconv.Quote(canonical)),
+				}},
+			}}
+		}
+
+		pass.Report(analysis.Diagnostic{
+			Pos: call.Args[0].Pos(),
+			End: call.Args[0].End(),
+			Message: "type " + strconv.Quote(typStr) + " in mock.AnythingOfType will never match because " +
+				"types are qualified by package name; use " + strconv.Quote(canonical),
+			SuggestedFixes: suggestedFixes,
+		})
+		return nil
+	}
+
+	var impls []types.Type
+	allInterfaces := true
+	for _, typ := range typs {
+		if types.IsInterface(typ) {
+			continue
+		}
+
+		allInterfaces = false
+		if types.Implements(typ, getInterfaceType(iface)) {
+			impls = append(impls, typ)
+		}
+	}
+
+	if len(impls) == 1 {
+		return impls[0]
+	} else if len(impls) > 1 {
+		return nil
+	}
+
+	if allInterfaces {
+		pass.Reportf(
+			call.Args[0].Pos(),
+			"type %q in mock.AnythingOfType is an interface, so it will never match; the type of an argument is always concrete",
+			typStr,
+		)
+		return nil
+	}
+
+	pass.Reportf(
+		call.Args[0].Pos(),
+		"type %q in mock.AnythingOfType doesn't implement %s, so it will never match",
+		typStr,
+		iface,
+	)
+	return nil
+}
+
+// typeStringResolver resolves the strings reflect uses to describe types (e.g. "*mypkg.Foo") to
+// types. The type of any argument must come from a package that's imported, directly or
+// indirectly, by the package being analyzed.
+type typeStringResolver struct {
+	pkgs []*types.Package
+	// aliases holds the packages that are imported with a different name in the package being
+	// analyzed.
+	aliases map[string]*types.Package
+	// local holds the types declared in functions of the package being analyzed, which aren't in
+	// its scope.
+	local map[string][]*types.TypeName
+}
+
+func newTypeStringResolver(pass *analysis.Pass) *typeStringResolver {
+	res := &typeStringResolver{
+		aliases: make(map[string]*types.Package),
+		local:   make(map[string][]*types.TypeName),
+	}
+
+	seen := make(map[*types.Package]struct{})
+	var visit func(pkg *types.Package)
+	visit = func(pkg *types.Package) {
+		if _, ok := seen[pkg]; ok {
+			return
+		}
+		seen[pkg] = struct{}{}
+		res.pkgs = append(res.pkgs, pkg)
+
+		for _, imp := range pkg.Imports() {
+			visit(imp)
+		}
+	}
+	visit(pass.Pkg)
+
+	for _, f := range pass.Files {
+		for _, imp := range f.Imports {
+			pkgName := pass.TypesInfo.PkgNameOf(imp)
+			if pkgName != nil && pkgName.Name() != pkgName.Imported().Name() {
+				res.aliases[pkgName.Name()] = pkgName.Imported()
+			}
+		}
+	}
+
+	for _, obj := range pass.TypesInfo.Defs {
+		tn, ok := obj.(*types.TypeName)
+		if !ok || tn.IsAlias() || tn.Parent() == pass.Pkg.Scope() {
+			continue
+		}
+		if _, isNamed := tn.Type().(*types.Named); isNamed {
+			res.local[tn.Name()] = append(res.local[tn.Name()], tn)
+		}
+	}
+
+	return res
+}
+
+// resolve returns the types that the type string could refer to, along with the string that
+// reflect would use for them. Unqualified names can refer to more than one type. It returns false
+// if the type string isn't one we understand.
+func (r *typeStringResolver) resolve(s string) ([]types.Type, string, bool) {
+	wrap := func(prefix, elem string, mk func(types.Type) types.Type) ([]types.Type, string, bool) {
+		elems, canonical, ok := r.resolve(elem)
+		if !ok {
+			return nil, "", false
+		}
+
+		typs := make([]types.Type, 0, len(elems))
+		for _, e := range elems {
+			typs = append(typs, mk(e))
+		}
+		return typs, prefix + canonical, true
+	}
+
+	switch {
+	case strings.HasPrefix(s, "*"):
+		return wrap("*", s[1:], func(t types.Type) types.Type { return types.NewPointer(t) })
+	case strings.HasPrefix(s, "[]"):
+		return wrap("[]", s[2:], func(t types.Type) types.Type { return types.NewSlice(t) })
+	case strings.HasPrefix(s, "["):
+		end := strings.Index(s, "]")
+		if end < 0 {
+			return nil, "", false
+		}
+
+		n, err := strconv.ParseInt(s[1:end], 10, 64)
+		if err != nil {
+			return nil, "", false
+		}
+		return wrap(s[:end+1], s[end+1:], func(t types.Type) types.Type { return types.NewArray(t, n) })
+	case strings.HasPrefix(s, "map["):
+		end := matchingBracket(s, len("map"))
+		if end < 0 {
+			return nil, "", false
+		}
+
+		keys, keyStr, ok := r.resolve(s[len("map["):end])
+		if !ok || len(keys) == 0 {
+			return nil, keyStr, ok
+		}
+
+		// Unqualified key types are rare enough that we'll only consider the first.
+		return wrap("map["+keyStr+"]", s[end+1:], func(t types.Type) types.Type {
+			return types.NewMap(keys[0], t)
+		})
+	default:
+		return r.resolveNamed(s)
+	}
+}
+
+func (r *typeStringResolver) resolveNamed(s string) ([]types.Type, string, bool) {
+	qualifier, name, qualified := cutLast(s, ".")
+	if !token.IsIdentifier(name) {
+		return nil, "", false
+	}
+
+	if !qualified {
+		// reflect.Type.Name doesn't include the package, so an unqualified name could be any type
+		// with that name.
+		if obj, ok := types.Universe.Lookup(name).(*types.TypeName); ok {
+			return []types.Type{obj.Type()}, s, true
+		}
+
+		typs := r.lookupTypes(r.pkgs, name)
+		if len(typs) == 0 && !complete(r.pkgs) {
+			return nil, "", false
+		}
+		return typs, s, true
+	}
+
+	// The qualifier could also be an import path or an import alias, neither of which reflect uses.
+	var byName, byPath []*types.Package
+	for _, pkg := range r.pkgs {
+		switch {
+		case pkg.Name() == qualifier:
+			byName = append(byName, pkg)
+		case pkg.Path() == qualifier || r.aliases[qualifier] == pkg:
+			byPath = append(byPath, pkg)
+		}
+	}
+
+	if typs := r.lookupTypes(byName, name); len(typs) > 0 {
+		return typs, s, true
+	}
+	for _, pkg := range byPath {
+		if typs := r.lookupTypes([]*types.Package{pkg}, name); len(typs) > 0 {
+			return typs[:1], pkg.Name() + "." + name, true
+		}
+	}
+
+	// We can only tell that the type doesn't exist if we know everything in the packages it could
+	// be in. Packages loaded from export data may only hold the parts that other packages use, and
+	// may not list all of their imports, in which case the package could be missing altogether.
+	searched := append(byName, byPath...)
+	if len(searched) == 0 {
+		searched = r.pkgs
+	}
+	if !complete(searched) {
+		return nil, "", false
+	}
+	return nil, s, true
+}
+
+// lookupTypes returns the types with the given name in the packages, including those declared in
+// functions of the package being analyzed. Aliases are skipped because reflect describes the
+// aliased type instead.
+func (r *typeStringResolver) lookupTypes(pkgs []*types.Package, name string) []types.Type {
+	var typs []types.Type
+	for _, pkg := range pkgs {
+		if obj, ok := pkg.Scope().Lookup(name).(*types.TypeName); ok && !obj.IsAlias() {
+			typs = append(typs, obj.Type())
+		}
+		for _, obj := range r.local[name] {
+			if obj.Pkg() == pkg {
+				typs = append(typs, obj.Type())
+			}
+		}
+	}
+	return typs
+}
+
+// complete returns whether we know all of the types declared at the top level of the packages.
+func complete(pkgs []*types.Package) bool {
+	for _, pkg := range pkgs {
+		if !pkg.Complete() {
+			return false
+		}
+	}
+	return true
+}
+
+// matchingBracket returns the index of the bracket that closes the one at index open, or -1.
+func matchingBracket(s string, open int) int {
+	depth := 0
+	for i := open; i < len(s); i++ {
+		switch s[i] {
+		case '[':
+			depth++
+		case ']':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// cutLast slices s around the last instance of sep.
+func cutLast(s, sep string) (before, after string, found bool) {
+	if i := strings.LastIndex(s, sep); i >= 0 {
+		return s[:i], s[i+len(sep):], true
+	}
+	return "", s, false
+}
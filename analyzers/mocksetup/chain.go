@@ -0,0 +1,241 @@
+package mocksetup
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"github.com/cszczepaniak/gomockcheck/analyzers/internal/typeutils"
+	"github.com/cszczepaniak/gomockcheck/names"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+// checkChain follows the *mock.Call chain of a mock setup and validates the calls in it against the
+// mocked method.
+func checkChain(pass *analysis.Pass, stack []ast.Node, mockDotOnCall *ast.CallExpr, mockedMethod *types.Selection) {
+	// Custom mock types don't necessarily return a *mock.Call, in which case there's nothing to
+	// check.
+	if !isMockCallType(pass.TypesInfo.TypeOf(mockDotOnCall)) {
+		return
+	}
+
+	sig := mockedMethod.Type().(*types.Signature)
+	chain, complete := callChain(pass.TypesInfo, stack)
+
+	hasReturn := false
+	for _, call := range chain {
+		switch chainedMethodName(call) {
+		case "Return":
+			hasReturn = true
+			checkReturnArgs(pass, call, mockedMethod.Obj().Name(), sig)
+		case "Panic", "Unset":
+			// Neither of these will ever return, so they don't need return values.
+			hasReturn = true
+		case "Run":
+			if isTypedCallMethod(pass.TypesInfo, call) {
+				runSig := types.NewSignatureType(nil, nil, nil, sig.Params(), nil, sig.Variadic())
+				checkTypedCallback(pass, call, mockedMethod.Obj().Name(), runSig)
+			} else {
+				checkRunCallback(pass, call, mockedMethod.Obj().Name(), sig)
+			}
+		case "RunAndReturn":
+			if isTypedCallMethod(pass.TypesInfo, call) {
+				hasReturn = true
+				checkTypedCallback(pass, call, mockedMethod.Obj().Name(), sig)
+			}
+		}
+	}
+
+	// If the chain isn't complete, the *mock.Call escapes and Return may be called elsewhere.
+	if !hasReturn && complete && sig.Results().Len() > 0 {
+		reportMissingReturn(pass, stack[0].(*ast.File), mockDotOnCall, mockedMethod)
+	}
+}
+
+// callChain returns the calls chained onto the *mock.Call that results from the call at the top
+// of the stack (e.g. the Return and Once in m.On("Foo").Return(nil).Once()), in source order. It
+// also reports whether the chain is complete, meaning that its result is discarded or that it
+// continues on to set up another call. If the *mock.Call escapes (e.g. it's assigned to a
+// variable), the chain may be continued elsewhere, so it's not complete.
+func callChain(info *types.Info, stack []ast.Node) (chain []*ast.CallExpr, complete bool) {
+	cur := stack[len(stack)-1]
+	i := len(stack) - 2
+	for ; i >= 1; i -= 2 {
+		sel, ok := stack[i].(*ast.SelectorExpr)
+		if !ok || sel.X != cur {
+			break
+		}
+
+		call, ok := stack[i-1].(*ast.CallExpr)
+		if !ok || call.Fun != sel || !isMockCallMethod(info, call) {
+			break
+		}
+
+		if sel.Sel.Name == "On" {
+			// This is a chained setup of another method, so this setup is done.
+			return chain, true
+		}
+
+		chain = append(chain, call)
+		cur = call
+	}
+
+	stmt, ok := stack[i].(*ast.ExprStmt)
+	return chain, ok && stmt.X == cur
+}
+
+// mockReceiver returns the type of the mock whose methods are referred to by name in a call to the
+// selected method, e.g. On. That's usually the receiver, but On can also be chained onto a
+// *mock.Call (which delegates to the mock the call was set up on), and any of the methods can be
+// called on the mock held by a *mock.Call's Parent field. It returns nil if the mock's type isn't
+// known, e.g. when the method is called on a bare mock.Mock.
+func (r *runner) mockReceiver(pass *analysis.Pass, sel *ast.SelectorExpr) types.Type {
+	selTyp, ok := pass.TypesInfo.Selections[sel]
+	if !ok {
+		return nil
+	}
+
+	if r.getEmbeddedMockType(selTyp.Recv()) != nil {
+		return selTyp.Recv()
+	}
+
+	x := ast.Unparen(sel.X)
+	if owner := r.shadowingMockOwner(pass.TypesInfo, x, selTyp.Obj().(*types.Func)); owner != nil {
+		return pass.TypesInfo.TypeOf(owner)
+	}
+
+	if isMockCallType(pass.TypesInfo.TypeOf(x)) {
+		return r.chainOrigin(pass, x)
+	}
+
+	if parent, ok := x.(*ast.SelectorExpr); ok && parent.Sel.Name == "Parent" &&
+		isMockCallType(pass.TypesInfo.TypeOf(parent.X)) {
+		return r.chainOrigin(pass, parent.X)
+	}
+
+	return nil
+}
+
+// shadowingMockOwner returns the expression holding a mock if expr explicitly selects the mock
+// embedded in it in order to call one of its methods that's shadowed by a mocked method, e.g. m in
+// m.Mock.On when the mocked interface has an On method. It returns nil otherwise; when a method
+// isn't shadowed, calling it on the embedded mock directly means that we don't know what's mocked.
+func (r *runner) shadowingMockOwner(info *types.Info, expr ast.Expr, method *types.Func) ast.Expr {
+	sel, ok := ast.Unparen(expr).(*ast.SelectorExpr)
+	if !ok {
+		return nil
+	}
+
+	selection, ok := info.Selections[sel]
+	if !ok || selection.Kind() != types.FieldVal {
+		return nil
+	}
+
+	field, ok := selection.Obj().(*types.Var)
+	if !ok || !field.Embedded() || r.getEmbeddedMockType(selection.Recv()) == nil {
+		return nil
+	}
+
+	obj, _, _ := types.LookupFieldOrMethod(selection.Recv(), true, method.Pkg(), method.Name())
+	if obj == nil || obj == method {
+		return nil
+	}
+
+	return sel.X
+}
+
+// chainOrigin returns the type of the mock that the *mock.Call chain ending in expr was set up on,
+// e.g. the type of m in m.On("Foo").Return(nil).Once(). It returns nil if it can't be determined,
+// e.g. because the chain starts with a variable.
+func (r *runner) chainOrigin(pass *analysis.Pass, expr ast.Expr) types.Type {
+	call, ok := ast.Unparen(expr).(*ast.CallExpr)
+	if !ok {
+		return nil
+	}
+
+	if m := r.expecterMethod(pass, call); m != nil {
+		return m.Recv()
+	}
+
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return nil
+	}
+
+	if nameFunc, ok := r.methodNameFunc(pass.TypesInfo, call); ok && nameFunc.name == "On" {
+		return r.mockReceiver(pass, sel)
+	}
+
+	if isMockCallMethod(pass.TypesInfo, call) {
+		return r.chainOrigin(pass, sel.X)
+	}
+
+	return nil
+}
+
+// callPos returns the position to report problems with a call at. For method calls, that's the
+// position of the method name rather than the start of the receiver, so that problems with a call
+// in a chain that spans several lines are reported on the call's own line.
+func callPos(call *ast.CallExpr) token.Pos {
+	if sel, ok := call.Fun.(*ast.SelectorExpr); ok {
+		return sel.Sel.Pos()
+	}
+	return call.Pos()
+}
+
+// chainedMethodName returns the name of the *mock.Call method invoked by a call in a chain.
+func chainedMethodName(call *ast.CallExpr) string {
+	return call.Fun.(*ast.SelectorExpr).Sel.Name
+}
+
+// isMockCallMethod returns whether the call is to a method on *mock.Call or on a mockery-generated
+// call type.
+func isMockCallMethod(info *types.Info, call *ast.CallExpr) bool {
+	fn := typeutil.StaticCallee(info, call)
+	if fn == nil {
+		return false
+	}
+
+	recv := fn.Signature().Recv()
+	return recv != nil && isMockCallType(recv.Type())
+}
+
+// isTypedCallMethod returns whether the call is to a method declared on a mockery-generated call
+// type, as opposed to one promoted from the *mock.Call it embeds.
+func isTypedCallMethod(info *types.Info, call *ast.CallExpr) bool {
+	fn := typeutil.StaticCallee(info, call)
+	if fn == nil {
+		return false
+	}
+
+	recv := fn.Signature().Recv()
+	return recv != nil && !names.IsTestifySymbol(typeutils.GetObjForPtrToNamedType(recv.Type()), "Call")
+}
+
+// isMockCallType returns whether the type is a *mock.Call or a pointer to a mockery-generated call
+// type, which embeds a *mock.Call and shadows some of its methods with typed versions.
+func isMockCallType(typ types.Type) bool {
+	obj := typeutils.GetObjForPtrToNamedType(typ)
+	if obj == nil {
+		return false
+	}
+
+	if names.IsTestifySymbol(obj, "Call") {
+		return true
+	}
+
+	s := getStructType(obj.Type())
+	if s == nil {
+		return false
+	}
+
+	for i := range s.NumFields() {
+		f := s.Field(i)
+		if f.Embedded() && names.IsTestifySymbol(typeutils.GetObjForPtrToNamedType(f.Type()), "Call") {
+			return true
+		}
+	}
+
+	return false
+}
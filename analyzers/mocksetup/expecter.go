@@ -0,0 +1,186 @@
+package mocksetup
+
+import (
+	"go/ast"
+	"go/types"
+	"strings"
+
+	"github.com/cszczepaniak/gomockcheck/analyzers/internal/typeutils"
+	"github.com/cszczepaniak/gomockcheck/names"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+// expecterMethod returns the mocked method for a call to one of the methods on a mockery-generated
+// expecter, e.g. the Get in m.EXPECT().Get(ctx, id). It returns nil if the call isn't to one of
+// these methods.
+//
+// For a mock type named MyMock, mockery generates an expecter type named MyMock_Expecter which
+// holds the *mock.Mock, with one method per mocked method. Each of these methods sets up the mocked
+// method with On and wraps the *mock.Call in a type named MyMock_Method_Call.
+func (r *runner) expecterMethod(pass *analysis.Pass, call *ast.CallExpr) *types.Selection {
+	fn := typeutil.StaticCallee(pass.TypesInfo, call)
+	if fn == nil {
+		return nil
+	}
+
+	recv := fn.Signature().Recv()
+	if recv == nil || fn.Signature().Results().Len() != 1 || !isMockCallType(fn.Signature().Results().At(0).Type()) {
+		return nil
+	}
+
+	expecter := typeutils.GetObjForPtrToNamedType(recv.Type())
+	if expecter == nil || expecter.Pkg() == nil {
+		return nil
+	}
+
+	mockName, ok := strings.CutSuffix(expecter.Name(), "_Expecter")
+	if !ok || !r.holdsMock(expecter.Type()) {
+		return nil
+	}
+
+	mockTyp, ok := expecter.Pkg().Scope().Lookup(mockName).(*types.TypeName)
+	if !ok {
+		return nil
+	}
+
+	typ := mockTyp.Type()
+	if r.getEmbeddedMockType(typ) == nil {
+		// The type is only named like a mock.
+		return nil
+	}
+	// The expecter's methods can be declared on an alias of it.
+	ptr, ok := recv.Type().(*types.Pointer)
+	if !ok {
+		return nil
+	}
+	named, ok := types.Unalias(ptr.Elem()).(*types.Named)
+	if !ok {
+		return nil
+	}
+
+	if targs := named.TypeArgs(); targs.Len() > 0 {
+		// The expecter of a generic mock has the same type parameters as the mock, so the mock has
+		// to be instantiated the same way for the mocked methods to have the right types.
+		args := make([]types.Type, targs.Len())
+		for i := range targs.Len() {
+			args[i] = targs.At(i)
+		}
+
+		inst, err := types.Instantiate(nil, typ, args, true)
+		if err != nil {
+			return nil
+		}
+		typ = inst
+	}
+
+	for m := range r.distinctMethods(pass.Pkg, types.NewPointer(typ)) {
+		if m.Obj().Name() == fn.Name() {
+			return m
+		}
+	}
+
+	return nil
+}
+
+// holdsMock returns whether the type is a struct with a field that points to a mock.
+func (r *runner) holdsMock(typ types.Type) bool {
+	s := getStructType(typ)
+	if s == nil {
+		return false
+	}
+
+	for i := range s.NumFields() {
+		obj := typeutils.GetObjForPtrToNamedType(s.Field(i).Type())
+		if obj != nil && names.IsOneOf(obj, r.types...) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// checkExpecterCall validates the arguments passed to an expecter method against the parameters of
+// the mocked method. The expecter's parameters are all interface{} so that they can accept
+// argument matchers like mock.Anything, which means the compiler can't check them for us. It
+// returns false if the expecter doesn't line up with the mocked method, in which case there's no
+// use in checking the rest of the setup.
+func (r *runner) checkExpecterCall(pass *analysis.Pass, file *ast.File, call *ast.CallExpr, mockedMethod *types.Selection) bool {
+	sig := mockedMethod.Type().(*types.Signature)
+	params := sig.Params()
+
+	args := call.Args
+	numParams := params.Len()
+	wantAt := func(i int) types.Type {
+		return params.At(i).Type()
+	}
+
+	expecterSig := typeutil.StaticCallee(pass.TypesInfo, call).Signature()
+	if sig.Variadic() && expecterSig.Variadic() {
+		// By default, mockery unrolls variadic arguments, so each of them is matched against the
+		// element type of the variadic parameter.
+		last := params.Len() - 1
+		wantAt = func(i int) types.Type {
+			if i < last {
+				return params.At(i).Type()
+			}
+			return params.At(last).Type().(*types.Slice).Elem()
+		}
+
+		if call.Ellipsis.IsValid() {
+			// We can't tell what's in the spread slice.
+			args = args[:len(args)-1]
+			numParams = last
+		} else {
+			numParams = max(len(args), last)
+		}
+	}
+
+	if len(args) != numParams {
+		// The expecter is out of date with the mocked method.
+		pass.Reportf(
+			callPos(call),
+			"call is mocked for %d arguments, but method %q takes %d",
+			len(args),
+			mockedMethod.Obj().Name(),
+			params.Len(),
+		)
+		return false
+	}
+
+	for i, arg := range args {
+		want := wantAt(i)
+		if !r.checkArg(pass, file, want, arg) {
+			pass.Reportf(
+				arg.Pos(),
+				"invalid parameter type in mock setup; %s is not assignable to %s",
+				pass.TypesInfo.TypeOf(arg),
+				want,
+			)
+		}
+	}
+
+	return true
+}
+
+// checkTypedCallback checks that the function passed to a typed Run or RunAndReturn on a
+// mockery-generated call type matches the mocked method. If the generated code is out of date, the
+// callback will panic when the mocked method is called.
+func checkTypedCallback(pass *analysis.Pass, call *ast.CallExpr, mockedMethodName string, want *types.Signature) {
+	if len(call.Args) != 1 {
+		return
+	}
+
+	got, ok := pass.TypesInfo.TypeOf(call.Args[0]).Underlying().(*types.Signature)
+	if !ok || types.Identical(got, want) {
+		return
+	}
+
+	pass.Reportf(
+		call.Args[0].Pos(),
+		"callback of type %s doesn't match method %q; it should be %s",
+		got,
+		mockedMethodName,
+		want,
+	)
+}
@@ -0,0 +1,126 @@
+package mocksetup
+
+import (
+	"go/ast"
+	"go/types"
+
+	"github.com/cszczepaniak/gomockcheck/names"
+	"golang.org/x/tools/go/analysis"
+)
+
+// fileQualifier returns a types.Qualifier that refers to packages by the names they're imported
+// with in the given file. If a package isn't imported by the file, ok is set to false because any
+// code printed with the qualifier won't compile as-is.
+func fileQualifier(pass *analysis.Pass, file *ast.File, ok *bool) types.Qualifier {
+	return func(pkg *types.Package) string {
+		if pkg.Path() == pass.Pkg.Path() {
+			return ""
+		}
+
+		for _, imp := range file.Imports {
+			pkgName := pass.TypesInfo.PkgNameOf(imp)
+			if pkgName == nil || pkgName.Imported().Path() != pkg.Path() {
+				continue
+			}
+
+			switch pkgName.Name() {
+			case "_":
+				continue
+			case ".":
+				return ""
+			default:
+				return pkgName.Name()
+			}
+		}
+
+		*ok = false
+		return pkg.Name()
+	}
+}
+
+// testifySymbol returns an expression referring to the symbol in the testify mock package, using
+// the name the package is imported with in the given file. It returns false if the file doesn't
+// import the package.
+func testifySymbol(pass *analysis.Pass, file *ast.File, name string) (string, bool) {
+	ok := true
+	qf := fileQualifier(pass, file, &ok)
+	pkgName := qf(types.NewPackage(names.TestifyMockPkg, "mock"))
+	if !ok {
+		return "", false
+	}
+
+	if pkgName == "" {
+		return name, true
+	}
+	return pkgName + "." + name, true
+}
+
+// zeroValues returns expressions for the zero values of each of the types in the tuple, printed
+// relative to the imports of the given file. It returns false if any of the zero values can't be
+// expressed in the file.
+func zeroValues(pass *analysis.Pass, file *ast.File, tuple *types.Tuple) ([]string, bool) {
+	ok := true
+	qf := fileQualifier(pass, file, &ok)
+
+	zeros := make([]string, 0, tuple.Len())
+	for i := range tuple.Len() {
+		zero, canExpress := zeroValue(tuple.At(i).Type(), qf)
+		if !canExpress {
+			return nil, false
+		}
+		zeros = append(zeros, zero)
+	}
+
+	return zeros, ok
+}
+
+// zeroValue returns an expression for the zero value of the type. The expression is typed such
+// that, when passed as an interface{}, its dynamic type is exactly the given type. That's what a
+// mock implementation expects when it asserts the type of a return value.
+func zeroValue(typ types.Type, qf types.Qualifier) (string, bool) {
+	if _, ok := typ.(*types.TypeParam); ok {
+		return "", false
+	}
+
+	switch u := typ.Underlying().(type) {
+	case *types.Basic:
+		var lit string
+		var def types.Type
+		switch {
+		case u.Info()&types.IsBoolean != 0:
+			lit, def = "false", types.Typ[types.Bool]
+		case u.Info()&types.IsString != 0:
+			lit, def = `""`, types.Typ[types.String]
+		case u.Info()&types.IsNumeric != 0:
+			lit, def = "0", types.Typ[types.Int]
+		case u.Kind() == types.UnsafePointer:
+			return typedNil(typ, qf), true
+		default:
+			return "nil", true
+		}
+
+		if types.Identical(typ, def) {
+			return lit, true
+		}
+		return types.TypeString(typ, qf) + "(" + lit + ")", true
+	case *types.Struct, *types.Array:
+		return types.TypeString(typ, qf) + "{}", true
+	case *types.Interface:
+		// A nil interface has no dynamic type, which is what a mock implementation expects for
+		// interfaces like error.
+		return "nil", true
+	default:
+		return typedNil(typ, qf), true
+	}
+}
+
+// typedNil returns a conversion of nil to the type, e.g. (*T)(nil) or []T(nil).
+func typedNil(typ types.Type, qf types.Qualifier) string {
+	name := types.TypeString(typ, qf)
+	switch typ.(type) {
+	case *types.Pointer, *types.Chan, *types.Signature:
+		// These types have to be parenthesized to be converted.
+		name = "(" + name + ")"
+	}
+	return name + "(nil)"
+}
@@ -0,0 +1,275 @@
+package mocksetup
+
+import (
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/types"
+	"slices"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+// setupHelperFact is a fact about a function that refers to a mocked method by name using its
+// parameters, e.g.
+//
+//	func expectGet(m *MyMock, id string) *mock.Call {
+//		return m.On("Get", id)
+//	}
+//
+// Calls to these functions are checked as if they were calls to the methods they wrap.
+type setupHelperFact struct {
+	Setups []helperSetup
+}
+
+func (*setupHelperFact) AFact() {}
+
+func (f *setupHelperFact) String() string {
+	setups := make([]string, 0, len(f.Setups))
+	for _, s := range f.Setups {
+		name := strconv.Quote(s.Name)
+		if s.NameParam >= 0 {
+			name = fmt.Sprintf("param %d", s.NameParam)
+		}
+		setups = append(setups, s.Func+"("+name+")")
+	}
+
+	return "setupHelper(" + strings.Join(setups, ", ") + ")"
+}
+
+// helperSetup describes a call to one of the methodNameFuncs (e.g. On) inside of a setup helper in
+// terms of the helper's parameters.
+type helperSetup struct {
+	// Func is the name of the method that's called, e.g. "On".
+	Func string
+	// MockParam is the index of the parameter holding the mock.
+	MockParam int
+	// Name is the name of the mocked method if it's a constant. Otherwise, NameParam is the index
+	// of the parameter holding the name.
+	Name      string
+	NameParam int
+	// ArgParams holds the index of the parameter passed as each argument of the mocked method, or
+	// -1 if the argument isn't a parameter.
+	ArgParams []int
+	// Spread is whether the last of ArgParams is the helper's variadic parameter, spread into the
+	// arguments of the mocked method.
+	Spread bool
+}
+
+// exportSetupHelperFacts exports facts for the functions in the package that are setup helpers.
+// This has to happen before any of the calls in the package are checked, since helpers can be
+// called before they're declared.
+func (r *runner) exportSetupHelperFacts(pass *analysis.Pass) {
+	for _, file := range pass.Files {
+		for _, decl := range file.Decls {
+			fd, ok := decl.(*ast.FuncDecl)
+			if !ok || fd.Body == nil {
+				continue
+			}
+
+			fn, ok := pass.TypesInfo.Defs[fd.Name].(*types.Func)
+			if !ok {
+				continue
+			}
+
+			var fact setupHelperFact
+			ast.Inspect(fd.Body, func(n ast.Node) bool {
+				call, ok := n.(*ast.CallExpr)
+				if !ok {
+					return true
+				}
+
+				if setup, ok := r.helperSetup(pass.TypesInfo, fn, call); ok {
+					fact.Setups = append(fact.Setups, setup)
+				}
+				return true
+			})
+
+			if len(fact.Setups) > 0 {
+				pass.ExportObjectFact(fn, &fact)
+			}
+		}
+	}
+}
+
+// helperSetup describes the call in terms of the parameters of the function it's in. It returns
+// false if the call doesn't refer to a mocked method by name, or if none of the parameters flow
+// into the call.
+func (r *runner) helperSetup(info *types.Info, fn *types.Func, call *ast.CallExpr) (helperSetup, bool) {
+	nameFunc, ok := r.methodNameFunc(info, call)
+	if !ok {
+		return helperSetup{}, false
+	}
+
+	mockExpr := call.Fun.(*ast.SelectorExpr).X
+	if owner := r.shadowingMockOwner(info, mockExpr, typeutil.StaticCallee(info, call)); owner != nil {
+		mockExpr = owner
+	}
+
+	mockParam := paramIndex(info, fn, mockExpr)
+	if mockParam < 0 {
+		return helperSetup{}, false
+	}
+
+	setup := helperSetup{
+		Func:      nameFunc.name,
+		MockParam: mockParam,
+		NameParam: paramIndex(info, fn, call.Args[nameFunc.nameIdx]),
+	}
+
+	fromParams := setup.NameParam >= 0
+	if !fromParams {
+		tv, ok := info.Types[call.Args[nameFunc.nameIdx]]
+		if !ok || tv.Value == nil {
+			return helperSetup{}, false
+		}
+		setup.Name = constant.StringVal(tv.Value)
+	}
+
+	for _, arg := range call.Args[nameFunc.nameIdx+1:] {
+		idx := paramIndex(info, fn, arg)
+		setup.ArgParams = append(setup.ArgParams, idx)
+		fromParams = fromParams || idx >= 0
+	}
+
+	if call.Ellipsis.IsValid() {
+		last := len(setup.ArgParams) - 1
+		if last < 0 || !fn.Signature().Variadic() || setup.ArgParams[last] != fn.Signature().Params().Len()-1 {
+			// We can't know what's in the spread slice.
+			return helperSetup{}, false
+		}
+		setup.Spread = true
+	}
+
+	return setup, fromParams
+}
+
+// checkSetupHelperCall checks a call to a setup helper as if it were the calls to the methods it
+// wraps.
+func (r *runner) checkSetupHelperCall(pass *analysis.Pass, file *ast.File, call *ast.CallExpr) {
+	fn := typeutil.StaticCallee(pass.TypesInfo, call)
+	if fn == nil {
+		return
+	}
+
+	var fact setupHelperFact
+	if !pass.ImportObjectFact(fn.Origin(), &fact) {
+		return
+	}
+
+	for _, setup := range fact.Setups {
+		r.checkHelperSetup(pass, file, call, fn.Signature(), setup)
+	}
+}
+
+func (r *runner) checkHelperSetup(
+	pass *analysis.Pass,
+	file *ast.File,
+	call *ast.CallExpr,
+	helperSig *types.Signature,
+	setup helperSetup,
+) {
+	idx := slices.IndexFunc(methodNameFuncs, func(f methodNameFunc) bool { return f.name == setup.Func })
+	if idx < 0 || setup.MockParam >= len(call.Args) || setup.NameParam >= len(call.Args) {
+		return
+	}
+	nameFunc := methodNameFuncs[idx]
+
+	recv := pass.TypesInfo.TypeOf(call.Args[setup.MockParam])
+	if r.getEmbeddedMockType(recv) == nil {
+		return
+	}
+
+	var mockedMethod *types.Selection
+	if setup.NameParam >= 0 {
+		mockedMethod = r.lookupMockedMethod(pass, recv, call.Args[setup.NameParam])
+	} else {
+		// The helper has already been checked for using the wrong name.
+		for m := range r.distinctMethods(pass.Pkg, recv) {
+			if m.Obj().Name() == setup.Name {
+				mockedMethod = m
+				break
+			}
+		}
+	}
+
+	if mockedMethod == nil || nameFunc.verb == "" {
+		return
+	}
+
+	if nameFunc.name == "On" && setup.NameParam >= 0 {
+		checkRecorded(pass, call.Args[setup.NameParam], mockedMethod)
+	}
+
+	var mockedArgs []ast.Expr
+	for i, p := range setup.ArgParams {
+		if setup.Spread && i == len(setup.ArgParams)-1 {
+			if call.Ellipsis.IsValid() {
+				// We can't know what's in the spread slice.
+				return
+			}
+			mockedArgs = append(mockedArgs, call.Args[p:]...)
+			break
+		}
+
+		// If the helper names the method itself, the arguments have already been checked against
+		// the types of the helper's parameters. That's only worth doing again for interface{}
+		// parameters, which could be anything.
+		var arg ast.Expr
+		isVariadic := helperSig.Variadic() && p == helperSig.Params().Len()-1
+		if p >= 0 && !isVariadic &&
+			(setup.NameParam >= 0 || setup.Spread || isEmptyInterface(helperSig.Params().At(p).Type())) {
+			arg = call.Args[p]
+		}
+		mockedArgs = append(mockedArgs, arg)
+	}
+
+	if setup.NameParam < 0 && !setup.Spread {
+		// The number of arguments has already been checked in the helper.
+		if len(mockedArgs) != mockedMethod.Type().(*types.Signature).Params().Len() {
+			return
+		}
+	}
+
+	r.checkMockedArgs(pass, file, nameFunc, call, mockedMethod, mockedArgs, nil)
+}
+
+// enclosingFunc returns the function declared by the outermost function declaration in the stack,
+// or nil if there isn't one.
+func enclosingFunc(info *types.Info, stack []ast.Node) *types.Func {
+	for _, n := range stack {
+		if fd, ok := n.(*ast.FuncDecl); ok {
+			fn, _ := info.Defs[fd.Name].(*types.Func)
+			return fn
+		}
+	}
+	return nil
+}
+
+// paramIndex returns the index of the function's parameter that the expression refers to, or -1 if
+// it doesn't refer to one.
+func paramIndex(info *types.Info, fn *types.Func, expr ast.Expr) int {
+	id, ok := ast.Unparen(expr).(*ast.Ident)
+	if !ok {
+		return -1
+	}
+
+	obj := info.Uses[id]
+	params := fn.Signature().Params()
+	for i := range params.Len() {
+		if params.At(i) == obj {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// isEmptyInterface returns whether the type is interface{}, or an equivalent type like any.
+func isEmptyInterface(typ types.Type) bool {
+	iface, ok := typ.Underlying().(*types.Interface)
+	return ok && iface.Empty()
+}
@@ -0,0 +1,245 @@
+package mocksetup
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/cszczepaniak/gomockcheck/names"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+// suggestIsType reports a mock.AnythingOfType that can be replaced with mock.IsType, which refers
+// to the type itself rather than naming it in a string. That way, the compiler makes sure the type
+// exists and renaming it (or its package) doesn't silently break the mock setup. The replacement is
+// only suggested if the type fits the parameter and can be referred to where the
+// mock.AnythingOfType is.
+func suggestIsType(pass *analysis.Pass, file *ast.File, call *ast.CallExpr, want, typ types.Type) {
+	if !types.AssignableTo(typ, want) {
+		return
+	}
+
+	isType, ok := testifySymbol(pass, file, "IsType")
+	if !ok {
+		return
+	}
+
+	// Packages that the file doesn't import yet will have to be imported with their own names.
+	var missing []*types.Package
+	qf := func(pkg *types.Package) string {
+		ok := true
+		name := fileQualifier(pass, file, &ok)(pkg)
+		if !ok && !slices.Contains(missing, pkg) {
+			missing = append(missing, pkg)
+		}
+		return name
+	}
+
+	val, ok := zeroValue(typ, qf)
+	if !ok {
+		return
+	}
+
+	imported := make(map[string]bool)
+	for _, pkg := range missing {
+		if !canImport(pass.Pkg.Path(), pkg) || imported[pkg.Name()] {
+			return
+		}
+		imported[pkg.Name()] = true
+	}
+
+	if !canReferTo(pass, call.Pos(), typ, qf) {
+		return
+	}
+
+	edits := []analysis.TextEdit{{
+		Pos:     call.Pos(),
+		End:     call.End(),
+		NewText: []byte(isType + "(" + val + ")"),
+	}}
+
+	pass.Report(analysis.Diagnostic{
+		Pos:     call.Pos(),
+		End:     call.End(),
+		Message: fmt.Sprintf("mock.AnythingOfType can be replaced with the type-safe mock.IsType(%s)", val),
+		SuggestedFixes: []analysis.SuggestedFix{{
+			Message:   "replace with mock.IsType",
+			TextEdits: append(edits, addImports(file, missing)...),
+		}},
+	})
+}
+
+// handleMockIsType checks that the type given to a mock.IsType could match the parameter. testify
+// compares it to the dynamic type of the argument, which is always assignable to the parameter.
+// It returns false if the argument isn't a mock.IsType.
+func handleMockIsType(pass *analysis.Pass, want types.Type, arg ast.Expr) bool {
+	call, ok := arg.(*ast.CallExpr)
+	if !ok || len(call.Args) != 1 {
+		return false
+	}
+
+	callee := typeutil.StaticCallee(pass.TypesInfo, call)
+	if callee == nil || !names.IsTestifySymbol(callee, "IsType") {
+		return false
+	}
+
+	typ := pass.TypesInfo.TypeOf(call.Args[0])
+	if typ != nil && !types.AssignableTo(typ, want) {
+		pass.Reportf(call.Args[0].Pos(), "type %s in mock.IsType is not assignable to %s, so it will never match", typ, want)
+	}
+
+	// Return true because at this point we've seen a mock.IsType
+	return true
+}
+
+// canReferTo returns whether the type can be written at pos, qualified by qf. Each of the types
+// it's made of has to be exported if it's in another package, and its name (or its package's
+// name) has to refer to the same thing at pos as it does where it's declared. Packages that aren't
+// imported yet must not conflict with anything in scope at pos.
+func canReferTo(pass *analysis.Pass, pos token.Pos, typ types.Type, qf types.Qualifier) bool {
+	scope := pass.Pkg.Scope().Innermost(pos)
+	if scope == nil {
+		return false
+	}
+
+	isObj := func(obj types.Object) bool {
+		if obj.Pkg() == nil || qf(obj.Pkg()) == "" {
+			_, found := scope.LookupParent(obj.Name(), pos)
+			return found == obj
+		}
+
+		if !obj.Exported() {
+			return false
+		}
+
+		_, found := scope.LookupParent(qf(obj.Pkg()), pos)
+		if found == nil {
+			// The package will be imported.
+			return true
+		}
+
+		pkgName, ok := found.(*types.PkgName)
+		return ok && pkgName.Imported() == obj.Pkg()
+	}
+
+	var visit func(typ types.Type) bool
+	visitAll := func(typs ...types.Type) bool {
+		for _, typ := range typs {
+			if !visit(typ) {
+				return false
+			}
+		}
+		return true
+	}
+	visitTuple := func(tuple *types.Tuple) bool {
+		for i := range tuple.Len() {
+			if !visit(tuple.At(i).Type()) {
+				return false
+			}
+		}
+		return true
+	}
+	typeArgs := func(targs *types.TypeList) []types.Type {
+		typs := make([]types.Type, 0, targs.Len())
+		for i := range targs.Len() {
+			typs = append(typs, targs.At(i))
+		}
+		return typs
+	}
+
+	visit = func(typ types.Type) bool {
+		switch typ := typ.(type) {
+		case *types.Basic:
+			return typ.Kind() != types.UnsafePointer && isObj(types.Universe.Lookup(typ.Name()))
+		case *types.Named:
+			return isObj(typ.Obj()) && visitAll(typeArgs(typ.TypeArgs())...)
+		case *types.Alias:
+			return isObj(typ.Obj()) && visitAll(typeArgs(typ.TypeArgs())...)
+		case *types.Pointer:
+			return visit(typ.Elem())
+		case *types.Slice:
+			return visit(typ.Elem())
+		case *types.Array:
+			return visit(typ.Elem())
+		case *types.Chan:
+			return visit(typ.Elem())
+		case *types.Map:
+			return visitAll(typ.Key(), typ.Elem())
+		case *types.Signature:
+			return visitTuple(typ.Params()) && visitTuple(typ.Results())
+		case *types.Struct:
+			for i := range typ.NumFields() {
+				f := typ.Field(i)
+				// Unexported fields belong to their package, so the struct can't be written anywhere
+				// else.
+				if !f.Exported() && f.Pkg() != pass.Pkg || !visit(f.Type()) {
+					return false
+				}
+			}
+			return true
+		case *types.Interface:
+			return typ.Empty()
+		default:
+			return false
+		}
+	}
+
+	return visit(typ)
+}
+
+// canImport returns whether the package at path from is allowed to import pkg.
+func canImport(from string, pkg *types.Package) bool {
+	if pkg.Name() == "main" {
+		return false
+	}
+
+	// Packages in an internal directory can only be imported from within the directory's parent.
+	elems := strings.Split(pkg.Path(), "/")
+	for i := len(elems) - 1; i >= 0; i-- {
+		if elems[i] != "internal" {
+			continue
+		}
+
+		parent := strings.Join(elems[:i], "/")
+		return parent != "" && (from == parent || strings.HasPrefix(from, parent+"/"))
+	}
+
+	return true
+}
+
+// addImports returns edits that import the packages into the file.
+func addImports(file *ast.File, pkgs []*types.Package) []analysis.TextEdit {
+	if len(pkgs) == 0 {
+		return nil
+	}
+
+	var specs strings.Builder
+	for _, pkg := range pkgs {
+		specs.WriteString("\t" + strconv.Quote(pkg.Path()) + "\n")
+	}
+
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.IMPORT || !gen.Lparen.IsValid() {
+			continue
+		}
+
+		return []analysis.TextEdit{{
+			Pos:     gen.Rparen,
+			End:     gen.Rparen,
+			NewText: []byte(specs.String()),
+		}}
+	}
+
+	// There's no import group to add to, so add one after the package clause.
+	return []analysis.TextEdit{{
+		Pos:     file.Name.End(),
+		End:     file.Name.End(),
+		NewText: []byte("\n\nimport (\n" + specs.String() + ")"),
+	}}
+}
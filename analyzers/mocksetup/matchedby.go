@@ -0,0 +1,165 @@
+package mocksetup
+
+import (
+	"go/ast"
+	"go/types"
+
+	"github.com/cszczepaniak/gomockcheck/names"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+// matchKind describes whether the callback passed to mock.MatchedBy can be called with the
+// arguments of a parameter.
+type matchKind int
+
+const (
+	// alwaysCalled means the callback is called with every argument.
+	alwaysCalled matchKind = iota
+	// sometimesCalled means the callback is only called with some arguments, depending on their
+	// dynamic types. The matcher doesn't match the others.
+	sometimesCalled
+	// neverCalled means the callback is never called, so the matcher never matches.
+	neverCalled
+)
+
+// handleMockMatchedBy checks the callback passed to mock.MatchedBy against the parameter it will be
+// matched against. It returns false if the argument isn't a mock.MatchedBy.
+//
+// testify calls the callback with an argument only if the argument's dynamic type is assignable to
+// the callback's parameter type; otherwise the matcher doesn't match. When the parameter is an
+// interface, the dynamic type can be any implementation of it, so whether the matcher matches can
+// depend on the implementation.
+func handleMockMatchedBy(pass *analysis.Pass, want types.Type, arg ast.Expr) bool {
+	call := matchedByCall(pass.TypesInfo, arg)
+	if call == nil {
+		return false
+	}
+
+	callbackParam, ok := matchedByParam(pass.TypesInfo, call)
+	if !ok {
+		// testify panics if the callback isn't a func(T) bool.
+		pass.Reportf(call.Args[0].Pos(), "the argument to mock.MatchedBy must be func(%s) bool", want)
+		return true
+	}
+
+	switch matchedByCalled(want, callbackParam) {
+	case neverCalled:
+		pass.Reportf(call.Args[0].Pos(), "the argument to mock.MatchedBy must be func(%s) bool", want)
+	case sometimesCalled:
+		msg := "mock.MatchedBy callback takes %s, so it won't match every %s"
+		if !isNillable(callbackParam) {
+			// testify can't call the callback with a nil interface, so it panics instead.
+			msg += "; it also panics if the argument is nil"
+		}
+		pass.Reportf(call.Args[0].Pos(), msg, callbackParam, want)
+	}
+
+	// Return true because at this point we've seen a mock.MatchedBy
+	return true
+}
+
+// elemMatcher returns the callback passed to a mock.MatchedBy for a variadic parameter if it takes
+// an element of the variadic parameter rather than the whole slice. It returns nil otherwise.
+func elemMatcher(info *types.Info, want types.Type, arg ast.Expr) ast.Expr {
+	call := matchedByCall(info, arg)
+	if call == nil {
+		return nil
+	}
+
+	callbackParam, ok := matchedByParam(info, call)
+	if !ok || matchedByCalled(want, callbackParam) != neverCalled {
+		return nil
+	}
+
+	slice, ok := want.(*types.Slice)
+	if !ok || matchedByCalled(slice.Elem(), callbackParam) == neverCalled {
+		return nil
+	}
+
+	return call.Args[0]
+}
+
+// matchedByCall returns the argument as a call to mock.MatchedBy, or nil if it isn't one.
+func matchedByCall(info *types.Info, arg ast.Expr) *ast.CallExpr {
+	call, ok := arg.(*ast.CallExpr)
+	if !ok || len(call.Args) != 1 {
+		return nil
+	}
+
+	callee := typeutil.StaticCallee(info, call)
+	if callee == nil || !names.IsTestifySymbol(callee, "MatchedBy") {
+		return nil
+	}
+
+	return call
+}
+
+// matchedByParam returns the type of the parameter of the callback passed to mock.MatchedBy. It
+// returns false if testify won't accept the callback, i.e. it isn't a func with one parameter that
+// returns a bool.
+func matchedByParam(info *types.Info, call *ast.CallExpr) (types.Type, bool) {
+	fn, ok := info.TypeOf(call.Args[0]).Underlying().(*types.Signature)
+	if !ok || fn.Params().Len() != 1 || fn.Results().Len() != 1 {
+		return nil, false
+	}
+
+	// testify checks the kind of the result, so named bool types are fine.
+	res, ok := fn.Results().At(0).Type().Underlying().(*types.Basic)
+	if !ok || res.Kind() != types.Bool {
+		return nil, false
+	}
+
+	return fn.Params().At(0).Type(), true
+}
+
+// matchedByCalled models whether testify will call a mock.MatchedBy callback that takes
+// callbackParam with the arguments of a parameter of type want.
+func matchedByCalled(want, callbackParam types.Type) matchKind {
+	if types.AssignableTo(want, callbackParam) {
+		return alwaysCalled
+	}
+
+	wantIface, ok := want.Underlying().(*types.Interface)
+	if !ok {
+		// The dynamic type of the argument is always want.
+		return neverCalled
+	}
+
+	if paramIface, ok := callbackParam.Underlying().(*types.Interface); ok {
+		if conflictingMethod(want, paramIface) {
+			// No type can implement both interfaces.
+			return neverCalled
+		}
+		return sometimesCalled
+	}
+
+	if types.Implements(callbackParam, wantIface) {
+		return sometimesCalled
+	}
+	return neverCalled
+}
+
+// conflictingMethod returns whether the interface has a method with the same name as one of typ's,
+// but a different signature.
+func conflictingMethod(typ types.Type, iface *types.Interface) bool {
+	for i := range iface.NumMethods() {
+		m := iface.Method(i)
+		obj, _, _ := types.LookupFieldOrMethod(typ, false, m.Pkg(), m.Name())
+		if fn, ok := obj.(*types.Func); ok && !types.Identical(fn.Type(), m.Type()) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isNillable returns whether nil is a valid value of the type.
+func isNillable(typ types.Type) bool {
+	switch typ.Underlying().(type) {
+	case *types.Interface, *types.Chan, *types.Signature, *types.Map, *types.Slice, *types.Pointer:
+		return true
+	default:
+		return false
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"go/types"
 	"iter"
 	"slices"
+	"strings"
 
 	"github.com/cszczepaniak/gomockcheck/analyzers/internal/typeutils"
 	"github.com/cszczepaniak/gomockcheck/names"
@@ -24,19 +25,35 @@ func New(typs ...names.QualifiedType) *analysis.Analyzer {
 		}}, typs),
 	}
 
-	return &analysis.Analyzer{
-		Name:     "mocksetup",
-		Doc:      "Checks for common mock setup mistakes",
-		Run:      r.run,
-		Requires: []*analysis.Analyzer{inspect.Analyzer},
+	a := &analysis.Analyzer{
+		Name:      "mocksetup",
+		Doc:       "Checks for common mock setup mistakes",
+		Run:       r.run,
+		Requires:  []*analysis.Analyzer{inspect.Analyzer},
+		FactTypes: []analysis.Fact{new(setupHelperFact), new(nonRecordingFact)},
 	}
+	a.Flags.BoolVar(
+		&r.preferIsType,
+		"prefer-istype",
+		false,
+		"suggest replacing mock.AnythingOfType with mock.IsType for interface parameters",
+	)
+
+	return a
 }
 
 type runner struct {
 	types []names.QualifiedType
+
+	// preferIsType is whether to suggest replacing mock.AnythingOfType with the type-safe
+	// mock.IsType.
+	preferIsType bool
 }
 
 func (r *runner) run(pass *analysis.Pass) (any, error) {
+	r.exportSetupHelperFacts(pass)
+	r.exportNonRecordingFacts(pass)
+
 	inspector := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
 	inspector.WithStack(
 		[]ast.Node{&ast.CallExpr{}},
@@ -45,19 +62,25 @@ func (r *runner) run(pass *analysis.Pass) (any, error) {
 				return false
 			}
 
-			mockDotOnCall := n.(*ast.CallExpr)
-			if !r.isMockDotOn(pass.TypesInfo, mockDotOnCall) {
+			call := n.(*ast.CallExpr)
+			nameFunc, ok := r.methodNameFunc(pass.TypesInfo, call)
+			if !ok {
+				mockedMethod := r.expecterMethod(pass, call)
+				if mockedMethod == nil {
+					r.checkSetupHelperCall(pass, stack[0].(*ast.File), call)
+				} else if r.checkExpecterCall(pass, stack[0].(*ast.File), call, mockedMethod) {
+					checkChain(pass, stack, call, mockedMethod)
+				}
 				return true
 			}
 
-			if !r.checkMockDotOnCall(pass, mockDotOnCall) {
+			mockedMethod := r.checkMethodNameCall(pass, stack, call, nameFunc)
+			if mockedMethod == nil || nameFunc.name != "On" {
 				return true
 			}
 
-			// TODO check more things:
-			// - If the mocked call has return arguments, there must be a .Return
-			// - There must be the correct number of arguments to .Return
-			// - The arguments to .Return must be of the correct type
+			checkRecorded(pass, call.Args[nameFunc.nameIdx], mockedMethod)
+			checkChain(pass, stack, call, mockedMethod)
 			return true
 		},
 	)
@@ -65,110 +88,310 @@ func (r *runner) run(pass *analysis.Pass) (any, error) {
 	return nil, nil
 }
 
-func (r *runner) isMockDotOn(typesInfo *types.Info, c *ast.CallExpr) bool {
+// methodNameFunc describes a method on a mock that refers to one of the mocked methods by name.
+type methodNameFunc struct {
+	name string
+	// nameIdx is the index of the argument holding the name of the mocked method. The arguments
+	// following it are the arguments of the mocked method.
+	nameIdx int
+	// verb describes what the method does with the arguments of the mocked method, e.g. "mocked".
+	// It's empty if the method doesn't take the arguments of the mocked method.
+	verb string
+
+	// matchers is whether the arguments of the mocked method are matched against the actual
+	// arguments of calls, meaning that they can be argument matchers like mock.Anything.
+	matchers bool
+}
+
+var methodNameFuncs = []methodNameFunc{
+	{name: "On", nameIdx: 0, verb: "mocked", matchers: true},
+	{name: "MethodCalled", nameIdx: 0, verb: "recorded"},
+	{name: "AssertCalled", nameIdx: 1, verb: "asserted", matchers: true},
+	{name: "AssertNotCalled", nameIdx: 1, verb: "asserted", matchers: true},
+	{name: "AssertNumberOfCalls", nameIdx: 1},
+}
+
+// methodNameFunc returns the methodNameFunc for the call if it's to one of these methods on a
+// mock.
+func (r *runner) methodNameFunc(typesInfo *types.Info, c *ast.CallExpr) (methodNameFunc, bool) {
 	fn := typeutil.StaticCallee(typesInfo, c)
 	if fn == nil {
-		return false
+		return methodNameFunc{}, false
 	}
 
 	fnSig := fn.Signature()
 	recv := fnSig.Recv()
-	if recv == nil || fn.Name() != "On" {
-		return false
+	if recv == nil {
+		return methodNameFunc{}, false
+	}
+
+	idx := slices.IndexFunc(methodNameFuncs, func(f methodNameFunc) bool { return f.name == fn.Name() })
+	if idx < 0 || len(c.Args) <= methodNameFuncs[idx].nameIdx {
+		return methodNameFunc{}, false
 	}
 
 	obj := typeutils.GetObjForPtrToNamedType(recv.Type())
 	if obj == nil {
-		return false
+		return methodNameFunc{}, false
 	}
 
-	return names.IsOneOf(obj, r.types...)
+	// On can also be chained onto a *mock.Call to set up another method on the same mock.
+	isChainedOn := fn.Name() == "On" && names.IsTestifySymbol(obj, "Call")
+	if !isChainedOn && !names.IsOneOf(obj, r.types...) {
+		return methodNameFunc{}, false
+	}
+
+	return methodNameFuncs[idx], true
 }
 
-func (r *runner) checkMockDotOnCall(pass *analysis.Pass, mockDotOnCall *ast.CallExpr) bool {
-	// We know this function has at least one argument and that the first one is always a
-	// string. Let's check to make sure it's a constant and report a problem if it isn't.
-	typ, ok := pass.TypesInfo.Types[mockDotOnCall.Args[0]]
+// checkMethodNameCall validates the method name and arguments of a call that refers to a mocked
+// method by name, such as a mock setup. It returns the mocked method if further checks of the
+// call can be made, or nil otherwise.
+func (r *runner) checkMethodNameCall(pass *analysis.Pass, stack []ast.Node, call *ast.CallExpr, nameFunc methodNameFunc) *types.Selection {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
 	if !ok {
-		// This would be weird, right?
-		return false
+		return nil
 	}
 
-	if typ.Value == nil {
-		// We won't have a value if the argument isn't const. Let's report this.
-		pass.Reportf(mockDotOnCall.Args[0].Pos(), "the name of a mocked method should be a constant")
-		return false
+	recv := r.mockReceiver(pass, sel)
+	if recv == nil {
+		return nil
 	}
 
-	mockedMethodName := constant.StringVal(typ.Value)
+	nameArg := call.Args[nameFunc.nameIdx]
+	if helper := enclosingFunc(pass.TypesInfo, stack); helper != nil && paramIndex(pass.TypesInfo, helper, nameArg) >= 0 {
+		// The method is named by the callers of this setup helper, so it's checked at the call
+		// sites instead.
+		return nil
+	}
 
-	sel, ok := mockDotOnCall.Fun.(*ast.SelectorExpr)
-	if !ok {
-		return false
+	mockedMethod := r.lookupMockedMethod(pass, recv, nameArg)
+	if mockedMethod == nil || nameFunc.verb == "" {
+		return mockedMethod
+	}
+
+	if call.Ellipsis.IsValid() {
+		// We can't know what's in a spread slice.
+		return mockedMethod
 	}
 
-	selTyp, ok := pass.TypesInfo.Selections[sel]
+	// Exclude the method name (and anything before it) from the args supplied for the mocked
+	// method.
+	mockedArgs := call.Args[nameFunc.nameIdx+1:]
+	if !r.checkMockedArgs(pass, stack[0].(*ast.File), nameFunc, call, mockedMethod, mockedArgs, nameArg) {
+		return nil
+	}
+
+	return mockedMethod
+}
+
+// lookupMockedMethod returns the method of the mock named by the argument. If the name isn't a
+// constant or there's no such method, it reports the problem and returns nil.
+func (r *runner) lookupMockedMethod(pass *analysis.Pass, recv types.Type, nameArg ast.Expr) *types.Selection {
+	// We know the method name is always a string. Let's check to make sure it's a constant and
+	// report a problem if it isn't.
+	typ, ok := pass.TypesInfo.Types[nameArg]
 	if !ok {
-		return false
+		// This would be weird, right?
+		return nil
 	}
 
-	var mockedMethod *types.Selection
-	for m := range r.distinctMethods(pass.Pkg, selTyp.Recv()) {
+	if typ.Value == nil {
+		// We won't have a value if the argument isn't const. Let's report this.
+		pass.Reportf(nameArg.Pos(), "the name of a mocked method should be a constant")
+		return nil
+	}
+
+	mockedMethodName := constant.StringVal(typ.Value)
+
+	var methodNames []string
+	for m := range r.distinctMethods(pass.Pkg, recv) {
 		if m.Obj().Name() == mockedMethodName {
-			mockedMethod = m
-			break
+			return m
 		}
+		methodNames = append(methodNames, m.Obj().Name())
 	}
 
-	if mockedMethod == nil {
-		pass.Reportf(mockDotOnCall.Args[0].Pos(), "%q is not a method of %s", mockedMethodName, selTyp.Recv())
+	reportUnknownMethod(pass, nameArg, mockedMethodName, recv, methodNames)
+	return nil
+}
+
+// checkMockedArgs validates the arguments given for a mocked method in a call that refers to it by
+// name. Arguments that aren't known are nil; only their count is checked. Fixes are only suggested
+// if nameArg, the argument preceding the mocked method's arguments in the call, is given. It
+// returns false if the arguments don't line up with the method's parameters.
+func (r *runner) checkMockedArgs(
+	pass *analysis.Pass,
+	file *ast.File,
+	nameFunc methodNameFunc,
+	call *ast.CallExpr,
+	mockedMethod *types.Selection,
+	mockedArgs []ast.Expr,
+	nameArg ast.Expr,
+) bool {
+	mockedMethodName := mockedMethod.Obj().Name()
+	sig := mockedMethod.Type().(*types.Signature)
+	if spread := spreadVariadicArgs(pass.TypesInfo, sig, mockedArgs); spread != nil {
+		var suggestedFixes []analysis.SuggestedFix
+		variadic := sig.Params().At(sig.Params().Len() - 1)
+		if nameArg != nil {
+			suggestedFixes = variadicSliceFixes(pass, file, variadic.Type(), spread)
+		}
+
+		pass.Report(analysis.Diagnostic{
+			Pos: spread[0].Pos(),
+			End: spread[len(spread)-1].End(),
+			Message: fmt.Sprintf(
+				"variadic parameter %q of method %q is %s for %d separate arguments (hint: last parameter is variadic, make it a slice)",
+				variadic.Name(),
+				mockedMethodName,
+				nameFunc.verb,
+				len(spread),
+			),
+			SuggestedFixes: suggestedFixes,
+		})
 		return false
 	}
 
-	// Exclude the method name from the number of args supplied to the mock setup.
-	numMockedArgs := len(mockDotOnCall.Args) - 1
+	if sig.Params().Len() != len(mockedArgs) {
+		var suggestedFixes []analysis.SuggestedFix
+		if nameFunc.matchers && nameArg != nil {
+			suggestedFixes = argCountFixes(pass, file, nameArg, mockedArgs, sig.Params().Len())
+		}
 
-	sig := mockedMethod.Type().(*types.Signature)
-	if sig.Params().Len() != numMockedArgs {
-		pass.Reportf(
-			mockDotOnCall.Pos(),
-			"call is mocked for %d arguments, but method %q takes %d",
-			numMockedArgs,
-			mockedMethodName,
-			sig.Params().Len(),
-		)
+		pass.Report(analysis.Diagnostic{
+			Pos: callPos(call),
+			End: call.End(),
+			Message: fmt.Sprintf(
+				"call is %s for %d arguments, but method %q takes %d",
+				nameFunc.verb,
+				len(mockedArgs),
+				mockedMethodName,
+				sig.Params().Len(),
+			),
+			SuggestedFixes: suggestedFixes,
+		})
 		return false
 	}
 
-	for i, arg := range mockDotOnCall.Args[1:] {
+	for i, arg := range mockedArgs {
+		if arg == nil {
+			continue
+		}
+
 		want := sig.Params().At(i).Type()
+		if i == len(mockedArgs)-1 && sig.Variadic() {
+			if callback := elemMatcher(pass.TypesInfo, want, arg); callback != nil {
+				pass.Reportf(
+					callback.Pos(),
+					"the argument to mock.MatchedBy must be func(%s) bool (hint: last parameter is variadic, so the matcher is called with all of the variadic arguments as a slice)",
+					want,
+				)
+				continue
+			}
+		}
 
-		switch {
-		case isMockAnything(pass.TypesInfo, arg):
-			continue
-		case handleMockAnythingOfType(pass, want, arg):
-			continue
-		case handleMockMatchedBy(pass, want, arg):
+		if r.checkArg(pass, file, want, arg) {
 			continue
 		}
 
 		argTyp := pass.TypesInfo.TypeOf(arg)
-		if !types.AssignableTo(argTyp, want) && !types.Identical(argTyp, types.Universe.Lookup("any").Type()) {
-			msg := fmt.Sprintf("invalid parameter type in mock setup; %s is not assignable to %s", argTyp, want)
-			if i == len(mockDotOnCall.Args)-2 &&
-				// If we wanted []T but had T for the variadic parameter we'll add more help.
-				sig.Variadic() &&
-				types.Identical(want, types.NewSlice(pass.TypesInfo.TypeOf(arg))) {
-				msg += " (hint: last parameter is variadic, make it a slice)"
+		msg := fmt.Sprintf("invalid parameter type in %s; %s is not assignable to %s", nameFunc.describe(), argTyp, want)
+
+		var suggestedFixes []analysis.SuggestedFix
+		if i == len(mockedArgs)-1 &&
+			// If we wanted []T but had T for the variadic parameter we'll add more help.
+			sig.Variadic() &&
+			fitsElem(pass.TypesInfo, arg, want.(*types.Slice).Elem()) {
+			msg += " (hint: last parameter is variadic, make it a slice)"
+			if nameArg != nil {
+				suggestedFixes = variadicSliceFixes(pass, file, want, mockedArgs[i:])
 			}
-
-			pass.Reportf(arg.Pos(), msg)
 		}
+
+		pass.Report(analysis.Diagnostic{
+			Pos:            arg.Pos(),
+			End:            arg.End(),
+			Message:        msg,
+			SuggestedFixes: suggestedFixes,
+		})
 	}
 
 	return true
 }
 
+// argCountFixes returns fixes for a call with the wrong number of arguments for the mocked method.
+// Missing arguments are filled in with mock.Anything, and extra arguments are removed as long as
+// they're all mock.Anything; otherwise, we can't know which arguments are the extra ones.
+func argCountFixes(pass *analysis.Pass, file *ast.File, nameArg ast.Expr, args []ast.Expr, want int) []analysis.SuggestedFix {
+	if len(args) < want {
+		anything, ok := testifySymbol(pass, file, "Anything")
+		if !ok {
+			return nil
+		}
+
+		pos := nameArg.End()
+		if len(args) > 0 {
+			pos = args[len(args)-1].End()
+		}
+
+		return []analysis.SuggestedFix{{
+			Message: "add mock.Anything for the missing arguments",
+			TextEdits: []analysis.TextEdit{{
+				Pos:     pos,
+				End:     pos,
+				NewText: []byte(strings.Repeat(", "+anything, want-len(args))),
+			}},
+		}}
+	}
+
+	for _, arg := range args[want:] {
+		if !isMockAnything(pass.TypesInfo, arg) {
+			return nil
+		}
+	}
+
+	pos := nameArg.End()
+	if want > 0 {
+		pos = args[want-1].End()
+	}
+
+	return []analysis.SuggestedFix{{
+		Message: "remove the extra mock.Anything arguments",
+		TextEdits: []analysis.TextEdit{{
+			Pos: pos,
+			End: args[len(args)-1].End(),
+		}},
+	}}
+}
+
+// describe describes a call to the method for use in diagnostics.
+func (f methodNameFunc) describe() string {
+	if f.name == "On" {
+		return "mock setup"
+	}
+	return "call to " + f.name
+}
+
+// checkArg validates an argument of a mock setup against the type of the parameter it will be
+// matched against. It returns false if the argument's type is invalid, which the caller should
+// report. Other problems are reported here.
+func (r *runner) checkArg(pass *analysis.Pass, file *ast.File, want types.Type, arg ast.Expr) bool {
+	switch {
+	case isMockAnything(pass.TypesInfo, arg):
+		return true
+	case r.handleMockAnythingOfType(pass, file, want, arg):
+		return true
+	case handleMockMatchedBy(pass, want, arg):
+		return true
+	case handleMockIsType(pass, want, arg):
+		return true
+	}
+
+	argTyp := pass.TypesInfo.TypeOf(arg)
+	return types.AssignableTo(argTyp, want) || types.Identical(argTyp, types.Universe.Lookup("any").Type())
+}
+
 func isMockAnything(info *types.Info, arg ast.Expr) bool {
 	var obj types.Object
 	switch arg := arg.(type) {
@@ -181,7 +404,7 @@ func isMockAnything(info *types.Info, arg ast.Expr) bool {
 	return names.IsTestifySymbol(obj, "Anything")
 }
 
-func handleMockAnythingOfType(pass *analysis.Pass, want types.Type, arg ast.Expr) bool {
+func (r *runner) handleMockAnythingOfType(pass *analysis.Pass, file *ast.File, want types.Type, arg ast.Expr) bool {
 	call, ok := arg.(*ast.CallExpr)
 	if !ok {
 		return false
@@ -197,9 +420,13 @@ func handleMockAnythingOfType(pass *analysis.Pass, want types.Type, arg ast.Expr
 	}
 
 	// If the actual type is an interface, the AnythingOfType is likely asserting that the type
-	// passed in is a specific implementation of that interface. We can't do much more to verify,
-	// but we should consider it "handled" because this was a mock.AnythingOfType.
+	// passed in is a specific implementation of that interface. Make sure that implementation
+	// exists and is named the way testify will see it.
 	if getInterfaceType(want) != nil {
+		typ := checkAnythingOfTypeString(pass, want, call)
+		if typ != nil && r.preferIsType {
+			suggestIsType(pass, file, call, want, typ)
+		}
 		return true
 	}
 
@@ -235,46 +462,8 @@ func handleMockAnythingOfType(pass *analysis.Pass, want types.Type, arg ast.Expr
 	return true
 }
 
-func handleMockMatchedBy(pass *analysis.Pass, want types.Type, arg ast.Expr) bool {
-	call, ok := arg.(*ast.CallExpr)
-	if !ok {
-		return false
-	}
-
-	callee := typeutil.StaticCallee(pass.TypesInfo, call)
-	if callee == nil {
-		return false
-	}
-
-	if !names.IsTestifySymbol(callee, "MatchedBy") {
-		return false
-	}
-
-	report := func() {
-		pass.Reportf(call.Args[0].Pos(), "the argument to mock.MatchedBy must be func(%s) bool", want)
-	}
-
-	argTyp := pass.TypesInfo.TypeOf(call.Args[0])
-	fn, ok := argTyp.(*types.Signature)
-	if !ok {
-		report()
-		return true
-	}
-
-	boolTyp := types.Universe.Lookup("bool").Type()
-	if fn.Params().Len() != 1 ||
-		!types.Identical(want, fn.Params().At(0).Type()) ||
-		fn.Results().Len() != 1 ||
-		!types.Identical(boolTyp, fn.Results().At(0).Type()) {
-		report()
-	}
-
-	// Return true because at this point we've seen a properly configured mock.MatchedBy
-	return true
-}
-
 func getInterfaceType(typ types.Type) *types.Interface {
-	switch typ := typ.(type) {
+	switch typ := types.Unalias(typ).(type) {
 	case *types.Interface:
 		return typ
 	case *types.Named:
@@ -284,56 +473,21 @@ func getInterfaceType(typ types.Type) *types.Interface {
 	}
 }
 
-// distinctMethods returns the methods on this type that aren't on the mock type. Precondition:
-// setMockType has been called.
+// distinctMethods returns the methods on this type that aren't on the mock type it embeds.
 func (r *runner) distinctMethods(pkg *types.Package, typ types.Type) iter.Seq[*types.Selection] {
-	mockTyp := r.getEmbeddedMockType(typ)
-	mockMethodSet := types.NewMethodSet(mockTyp)
-	unexportedMockMethods := make(map[string]struct{})
-	for m := range iterMethodSet(mockMethodSet) {
-		if !m.Obj().Exported() {
-			unexportedMockMethods[m.Obj().Name()] = struct{}{}
-		}
-	}
-
-	return func(yield func(*types.Selection) bool) {
-		mSet := types.NewMethodSet(typ)
-		for method := range iterMethodSet(mSet) {
-			_, isUnexpectedMockMethod := unexportedMockMethods[method.Obj().Name()]
-			if isUnexpectedMockMethod {
-				continue
-			}
-
-			mockMethod := mockMethodSet.Lookup(pkg, method.Obj().Name())
-			if mockMethod == nil && !yield(method) {
-				return
-			}
-		}
-	}
+	return typeutils.DistinctMethods(pkg, typ, r.isMockObj)
 }
 
 func (r *runner) getEmbeddedMockType(typ types.Type) types.Type {
-	s := getStructType(typ)
-	for i := range s.NumFields() {
-		field := s.Field(i)
-		if !field.Embedded() {
-			continue
-		}
-
-		named, ok := field.Type().(*types.Named)
-		if !ok {
-			continue
-		}
+	return typeutils.EmbeddedMockType(typ, r.isMockObj)
+}
 
-		if names.IsOneOf(named.Obj(), r.types...) {
-			return named
-		}
-	}
-	return nil
+func (r *runner) isMockObj(obj types.Object) bool {
+	return names.IsOneOf(obj, r.types...)
 }
 
 func getStructType(typ types.Type) *types.Struct {
-	switch typ := typ.(type) {
+	switch typ := types.Unalias(typ).(type) {
 	case *types.Struct:
 		return typ
 	case *types.Named:
@@ -344,13 +498,3 @@ func getStructType(typ types.Type) *types.Struct {
 		return nil
 	}
 }
-
-func iterMethodSet(mSet *types.MethodSet) iter.Seq[*types.Selection] {
-	return func(yield func(*types.Selection) bool) {
-		for i := range mSet.Len() {
-			if !yield(mSet.At(i)) {
-				return
-			}
-		}
-	}
-}
@@ -1,6 +1,9 @@
 package mocksetup
 
 import (
+	"os"
+	"path/filepath"
+	"regexp"
 	"testing"
 
 	"golang.org/x/tools/go/analysis/analysistest"
@@ -13,3 +16,58 @@ func TestMockSetup(t *testing.T) {
 func TestMockSetup_SuggestedFixes(t *testing.T) {
 	analysistest.RunWithSuggestedFixes(t, analysistest.TestData(), New(), "./suggestedfixes")
 }
+
+func TestMockSetup_PreferIsType(t *testing.T) {
+	a := New()
+	if err := a.Flags.Set("prefer-istype", "true"); err != nil {
+		t.Fatal(err)
+	}
+
+	analysistest.RunWithSuggestedFixes(t, filepath.Join(analysistest.TestData(), "istype"), a, "./...")
+}
+
+func TestMockSetup_PreferIsTypeFixed(t *testing.T) {
+	a := New()
+	if err := a.Flags.Set("prefer-istype", "true"); err != nil {
+		t.Fatal(err)
+	}
+
+	// The fixed code shouldn't have anything left to report.
+	dir := withGoldenFiles(t, filepath.Join(analysistest.TestData(), "istype"))
+	analysistest.Run(t, dir, a, "./...")
+}
+
+var wantComment = regexp.MustCompile(`(?m)[ \t]*// want .*$`)
+
+// withGoldenFiles returns a copy of the directory in which each file with a .golden file is
+// replaced by it, without its expectations.
+func withGoldenFiles(t *testing.T, src string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	if err := os.CopyFS(dir, os.DirFS(src)); err != nil {
+		t.Fatal(err)
+	}
+
+	goldens, err := filepath.Glob(filepath.Join(dir, "*.golden"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, golden := range goldens {
+		b, err := os.ReadFile(golden)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		b = wantComment.ReplaceAll(b, nil)
+		if err := os.WriteFile(golden[:len(golden)-len(".golden")], b, 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Remove(golden); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return dir
+}
@@ -0,0 +1,133 @@
+package mocksetup
+
+import (
+	"go/ast"
+	"go/types"
+
+	"github.com/cszczepaniak/gomockcheck/analyzers/internal/typeutils"
+	"github.com/cszczepaniak/gomockcheck/names"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+// nonRecordingFact is a fact about a method of a mock that never records its calls with Called (or
+// MethodCalled), e.g. a stub that returns hard-coded values. Setting up such a method with On is
+// pointless; the setup is never matched, so AssertExpectations fails unless it's optional.
+type nonRecordingFact struct{}
+
+func (*nonRecordingFact) AFact() {}
+
+func (*nonRecordingFact) String() string { return "nonRecording" }
+
+// exportNonRecordingFacts exports facts for the methods of the package's mocks that don't record
+// their calls. Only mocks that record the calls of some of their methods are considered; a mock
+// that never records any calls is more likely a fake that isn't meant to be set up with On.
+func (r *runner) exportNonRecordingFacts(pass *analysis.Pass) {
+	methods := make(map[*types.Func]*ast.FuncDecl)
+	for _, file := range pass.Files {
+		for _, decl := range file.Decls {
+			fd, ok := decl.(*ast.FuncDecl)
+			if !ok || fd.Recv == nil || fd.Body == nil {
+				continue
+			}
+
+			fn, ok := pass.TypesInfo.Defs[fd.Name].(*types.Func)
+			if ok && r.getEmbeddedMockType(fn.Signature().Recv().Type()) != nil {
+				methods[fn] = fd
+			}
+		}
+	}
+
+	// A method records its calls if it calls Called itself, or if it calls another method of the
+	// mock that does.
+	records := make(map[*types.Func]bool)
+	for changed := true; changed; {
+		changed = false
+		for fn, decl := range methods {
+			if records[fn] {
+				continue
+			}
+
+			if r.callsRecordingFunc(pass.TypesInfo, decl, records) {
+				records[fn] = true
+				changed = true
+			}
+		}
+	}
+
+	recordingTypes := make(map[*types.TypeName]bool)
+	for fn := range records {
+		recordingTypes[recvTypeName(fn)] = true
+	}
+
+	for fn := range methods {
+		if !records[fn] && recordingTypes[recvTypeName(fn)] {
+			pass.ExportObjectFact(fn, new(nonRecordingFact))
+		}
+	}
+}
+
+// callsRecordingFunc returns whether the body of the declaration calls Called or MethodCalled, or
+// one of the methods that are already known to record their calls.
+func (r *runner) callsRecordingFunc(info *types.Info, decl *ast.FuncDecl, records map[*types.Func]bool) bool {
+	found := false
+	ast.Inspect(decl.Body, func(n ast.Node) bool {
+		if found {
+			return false
+		}
+
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+
+		fn := typeutil.StaticCallee(info, call)
+		if fn == nil {
+			return true
+		}
+
+		if records[fn.Origin()] {
+			found = true
+			return false
+		}
+
+		recv := fn.Signature().Recv()
+		if recv == nil || (fn.Name() != "Called" && fn.Name() != "MethodCalled") {
+			return true
+		}
+
+		obj := typeutils.GetObjForPtrToNamedType(recv.Type())
+		found = obj != nil && names.IsOneOf(obj, r.types...)
+		return !found
+	})
+
+	return found
+}
+
+// checkRecorded reports a mock setup of a method that never records its calls.
+func checkRecorded(pass *analysis.Pass, nameArg ast.Expr, mockedMethod *types.Selection) {
+	fn, ok := mockedMethod.Obj().(*types.Func)
+	if !ok || !pass.ImportObjectFact(fn.Origin(), new(nonRecordingFact)) {
+		return
+	}
+
+	pass.Reportf(
+		nameArg.Pos(),
+		"method %q never records its calls with Called, so this mock setup will never be matched",
+		fn.Name(),
+	)
+}
+
+// recvTypeName returns the named type that the method is declared on.
+func recvTypeName(fn *types.Func) *types.TypeName {
+	typ := fn.Signature().Recv().Type()
+	if ptr, ok := typ.(*types.Pointer); ok {
+		typ = ptr.Elem()
+	}
+
+	named, ok := types.Unalias(typ).(*types.Named)
+	if !ok {
+		return nil
+	}
+	return named.Obj()
+}
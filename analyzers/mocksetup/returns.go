@@ -0,0 +1,71 @@
+package mocksetup
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+	"strings"
+
+	"github.com/cszczepaniak/gomockcheck/analyzers/internal/typeutils"
+	"golang.org/x/tools/go/analysis"
+)
+
+// reportMissingReturn reports a mock setup of a method with results that never calls Return,
+// suggesting a Return of zero values if they can be expressed in the file.
+func reportMissingReturn(pass *analysis.Pass, file *ast.File, mockDotOnCall *ast.CallExpr, mockedMethod *types.Selection) {
+	sig := mockedMethod.Type().(*types.Signature)
+
+	var suggestedFixes []analysis.SuggestedFix
+	if zeros, ok := zeroValues(pass, file, sig.Results()); ok {
+		suggestedFixes = []analysis.SuggestedFix{{
+			Message: "add .Return with zero values",
+			TextEdits: []analysis.TextEdit{{
+				Pos:     mockDotOnCall.End(),
+				End:     mockDotOnCall.End(),
+				NewText: []byte(".Return(" + strings.Join(zeros, ", ") + ")"),
+			}},
+		}}
+	}
+
+	pass.Report(analysis.Diagnostic{
+		Pos:            callPos(mockDotOnCall),
+		End:            mockDotOnCall.End(),
+		Message:        fmt.Sprintf("method %q has return values, but the mock setup has no call to Return", mockedMethod.Obj().Name()),
+		SuggestedFixes: suggestedFixes,
+	})
+}
+
+func checkReturnArgs(pass *analysis.Pass, returnCall *ast.CallExpr, mockedMethodName string, sig *types.Signature) {
+	// We can't know how many values are in a spread slice.
+	if returnCall.Ellipsis.IsValid() {
+		return
+	}
+
+	if len(returnCall.Args) != sig.Results().Len() {
+		pass.Reportf(
+			callPos(returnCall),
+			"call is mocked to return %d values, but method %q returns %d",
+			len(returnCall.Args),
+			mockedMethodName,
+			sig.Results().Len(),
+		)
+		return
+	}
+
+	for i, arg := range returnCall.Args {
+		if isMockAnything(pass.TypesInfo, arg) {
+			pass.Reportf(arg.Pos(), "mock.Anything is an argument matcher and can't be used as a return value")
+			continue
+		}
+
+		want := sig.Results().At(i).Type()
+		argTyp := pass.TypesInfo.TypeOf(arg)
+		if types.AssignableTo(argTyp, want) ||
+			types.Identical(argTyp, types.Universe.Lookup("any").Type()) ||
+			typeutils.IsReturnFunc(argTyp, sig, i) {
+			continue
+		}
+
+		pass.Reportf(arg.Pos(), "invalid return type in mock setup; %s is not assignable to %s", argTyp, want)
+	}
+}
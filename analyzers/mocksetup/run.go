@@ -0,0 +1,40 @@
+package mocksetup
+
+import (
+	"go/ast"
+	"go/types"
+
+	"github.com/cszczepaniak/gomockcheck/analyzers/internal/mockargs"
+	"golang.org/x/tools/go/analysis"
+)
+
+// checkRunCallback checks the uses of the mock.Arguments passed to a Run callback against the
+// parameters of the mocked method, since the callback receives the arguments the method was
+// called with.
+func checkRunCallback(pass *analysis.Pass, runCall *ast.CallExpr, mockedMethodName string, sig *types.Signature) {
+	if len(runCall.Args) != 1 {
+		return
+	}
+
+	// We can only follow the arguments into a function literal.
+	fn, ok := ast.Unparen(runCall.Args[0]).(*ast.FuncLit)
+	if !ok || len(fn.Type.Params.List) != 1 || len(fn.Type.Params.List[0].Names) != 1 {
+		return
+	}
+
+	args := pass.TypesInfo.Defs[fn.Type.Params.List[0].Names[0]]
+	if args == nil {
+		return
+	}
+
+	isArgs := func(expr ast.Expr) bool {
+		id, ok := ast.Unparen(expr).(*ast.Ident)
+		return ok && pass.TypesInfo.Uses[id] == args
+	}
+
+	mockargs.Check(pass, fn.Body, isArgs, mockargs.Values{
+		Method: mockedMethodName,
+		Kind:   "parameter",
+		Tuple:  sig.Params(),
+	})
+}
@@ -0,0 +1,131 @@
+package mocksetup
+
+import (
+	"cmp"
+	"fmt"
+	"go/ast"
+	"go/token"
+	"slices"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// maxSuggestions is the most method names we'll suggest for an unknown method name.
+const maxSuggestions = 3
+
+// reportUnknownMethod reports that the name passed to a mock isn't one of its mocked methods. If
+// any of the mocked methods have similar names, they're suggested. If there's one clear winner and
+// the name is a string literal, we'll also offer to replace it.
+func reportUnknownMethod(pass *analysis.Pass, nameArg ast.Expr, name string, recv fmt.Stringer, candidates []string) {
+	msg := fmt.Sprintf("%q is not a method of %s", name, recv)
+
+	suggestions, clearWinner := closestNames(name, candidates)
+	if len(suggestions) > 0 {
+		quoted := make([]string, 0, len(suggestions))
+		for _, s := range suggestions {
+			quoted = append(quoted, strconv.Quote(s))
+		}
+
+		msg += "; did you mean " + joinOr(quoted) + "?"
+	}
+
+	var suggestedFixes []analysis.SuggestedFix
+	lit, ok := ast.Unparen(nameArg).(*ast.BasicLit)
+	if clearWinner && ok && lit.Kind == token.STRING {
+		suggestedFixes = []analysis.SuggestedFix{{
+			Message: fmt.Sprintf("replace with %q", suggestions[0]),
+			TextEdits: []analysis.TextEdit{{
+				Pos:     lit.Pos(),
+				End:     lit.End(),
+				NewText: []byte(strconv.Quote(suggestions[0])),
+			}},
+		}}
+	}
+
+	pass.Report(analysis.Diagnostic{
+		Pos:            nameArg.Pos(),
+		End:            nameArg.End(),
+		Message:        msg,
+		SuggestedFixes: suggestedFixes,
+	})
+}
+
+// closestNames returns the candidates that are similar enough to the name to suggest in its
+// place, closest first. If one candidate is closer than all others, it's the only one returned and
+// the second return value is true.
+func closestNames(name string, candidates []string) ([]string, bool) {
+	type scored struct {
+		name string
+		dist int
+	}
+
+	// Allow roughly one typo for every three characters.
+	maxDist := max(1, len(name)/3)
+
+	var matches []scored
+	for _, c := range candidates {
+		// Distances are compared case-insensitively, but a difference in case only is closer than
+		// any typo, so everything else is one further away.
+		dist := 0
+		if !strings.EqualFold(name, c) {
+			d := editDistance(strings.ToLower(name), strings.ToLower(c))
+			if d > maxDist {
+				continue
+			}
+			dist = d + 1
+		}
+
+		matches = append(matches, scored{name: c, dist: dist})
+	}
+
+	slices.SortFunc(matches, func(a, b scored) int {
+		return cmp.Or(cmp.Compare(a.dist, b.dist), strings.Compare(a.name, b.name))
+	})
+
+	clearWinner := len(matches) == 1 || len(matches) > 1 && matches[0].dist < matches[1].dist
+	if clearWinner {
+		return []string{matches[0].name}, true
+	}
+
+	names := make([]string, 0, min(len(matches), maxSuggestions))
+	for _, m := range matches[:min(len(matches), maxSuggestions)] {
+		names = append(names, m.name)
+	}
+
+	return names, false
+}
+
+// editDistance returns the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	ar, br := []rune(a), []rune(b)
+
+	prev := make([]int, len(br)+1)
+	curr := make([]int, len(br)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := range ar {
+		curr[0] = i + 1
+		for j := range br {
+			cost := 1
+			if ar[i] == br[j] {
+				cost = 0
+			}
+			curr[j+1] = min(prev[j+1]+1, curr[j]+1, prev[j]+cost)
+		}
+		prev, curr = curr, prev
+	}
+
+	return prev[len(br)]
+}
+
+// joinOr joins the strings into a list like "a", "a or b", or "a, b or c".
+func joinOr(s []string) string {
+	if len(s) <= 1 {
+		return strings.Join(s, "")
+	}
+	return strings.Join(s[:len(s)-1], ", ") + " or " + s[len(s)-1]
+}
@@ -0,0 +1,74 @@
+package testdata
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"example.com/internal"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAnythingOfTypeInterfaceParam(t *testing.T) {
+	m := &MyMock{}
+
+	// These name implementations of io.Reader the way reflect does, so they're fine.
+	m.On("Method4", mock.AnythingOfType("*internal.Reader"))
+	m.On("Method4", mock.AnythingOfType("*strings.Reader"))
+
+	// reflect.Type.Name doesn't include the package, so testify also accepts the bare name of a
+	// named type. However, pointers don't have names, and no Reader implements io.Reader without
+	// being a pointer.
+	m.On("Method4", mock.AnythingOfType("Reader")) // want `type "Reader" in mock.AnythingOfType doesn't implement io.Reader, so it will never match`
+
+	// We don't understand these, so we leave them alone.
+	m.On("Method4", mock.AnythingOfType("func()"))
+	m.On("Method4", mock.AnythingOfType("struct {}"))
+
+	m.On("Method4", mock.AnythingOfType("*internal.Nope"))  // want `type "\*internal.Nope" in mock.AnythingOfType doesn't exist`
+	m.On("Method4", mock.AnythingOfType("*nopkg.Reader"))   // want `type "\*nopkg.Reader" in mock.AnythingOfType doesn't exist`
+	m.On("Method4", mock.AnythingOfType("internal.Reader")) // want `type "internal.Reader" in mock.AnythingOfType doesn't implement io.Reader, so it will never match`
+	m.On("Method4", mock.AnythingOfType("string"))          // want `type "string" in mock.AnythingOfType doesn't implement io.Reader, so it will never match`
+	m.On("Method4", mock.AnythingOfType("io.Reader"))       // want `type "io.Reader" in mock.AnythingOfType is an interface, so it will never match; the type of an argument is always concrete`
+
+	m.On("Method4", mock.AnythingOfType("*example.com/internal.Reader")) // want `type "\*example.com/internal.Reader" in mock.AnythingOfType will never match because types are qualified by package name; use "\*internal.Reader"`
+}
+
+type Reader = io.Reader
+
+type AliasMock struct {
+	mock.Mock
+}
+
+func (m *AliasMock) Read(r Reader) {}
+
+func TestAnythingOfTypeAliasParam(t *testing.T) {
+	m := &AliasMock{}
+
+	m.On("Read", mock.AnythingOfType("*strings.Reader"))
+	m.On("Read", mock.AnythingOfType("string")) // want `type "string" in mock.AnythingOfType doesn't implement example.com.Reader, so it will never match`
+}
+
+func TestAnythingOfTypeLocalType(t *testing.T) {
+	type localReader struct{ *strings.Reader }
+	type localString string
+
+	m := &MyMock{}
+
+	// Types declared in functions are named after the package too.
+	m.On("Method4", mock.AnythingOfType("testdata.localReader"))
+	m.On("Method4", mock.AnythingOfType("*testdata.localReader"))
+	m.On("Method4", mock.AnythingOfType("testdata.localString")) // want `type "testdata.localString" in mock.AnythingOfType doesn't implement io.Reader, so it will never match`
+}
+
+func TestIsType(t *testing.T) {
+	m := &MyMock{}
+
+	m.On("Method1", mock.IsType("")).Return(nil)
+	m.On("Method4", mock.IsType((*strings.Reader)(nil)))
+	m.On("Method4", mock.IsType(&internal.Reader{}))
+
+	m.On("Method1", mock.IsType(1)).Return(nil)     // want `type int in mock.IsType is not assignable to string, so it will never match`
+	m.On("Method4", mock.IsType(strings.Builder{})) // want `type strings.Builder in mock.IsType is not assignable to io.Reader, so it will never match`
+	m.On("Method2", mock.IsType(1), mock.IsType(true), "").Return(false, nil)
+}
@@ -2,6 +2,7 @@ package testdata
 
 import (
 	"bytes"
+	"io"
 	"os"
 	"strings"
 	"testing"
@@ -13,8 +14,8 @@ import (
 func TestMockAnything(t *testing.T) {
 	m := &MyMock{}
 	m.On("Method1", mock.Anything).Return(nil).Once()
-	m.On("Method2", mock.Anything, true, mock.Anything).Return(nil).Once()
-	m.On("Method3", mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+	m.On("Method2", mock.Anything, true, mock.Anything).Return(false, nil).Once()
+	m.On("Method3", mock.Anything, mock.Anything, mock.Anything).Return().Once()
 
 	// Arg counts still apply even with mock.Anything
 	m.On("Method1", mock.Anything, mock.Anything).Return(nil).Once()                               // want `call is mocked for 2 arguments, but method "Method1" takes 1`
@@ -53,31 +54,66 @@ func TestMockMatchedBy(t *testing.T) {
 		mock.MatchedBy(func(i int) bool { return false }),
 		mock.Anything,
 		mock.Anything,
-	).Return(nil).Once()
+	).Return(false, nil).Once()
 	m.On(
 		"Method2",
 		mock.MatchedBy(func(i int) {}), // want `the argument to mock.MatchedBy must be func\(int\) bool`
 		mock.Anything,
 		mock.Anything,
-	).Return(nil).Once()
+	).Return(false, nil).Once()
 	m.On(
 		"Method2",
 		mock.MatchedBy(func(s string) bool { return false }), // want `the argument to mock.MatchedBy must be func\(int\) bool`
 		mock.Anything,
 		mock.Anything,
-	).Return(nil).Once()
+	).Return(false, nil).Once()
 	m.On(
 		"Method2",
 		mock.MatchedBy("wrong"), // want `the argument to mock.MatchedBy must be func\(int\) bool`
 		mock.Anything,
 		mock.Anything,
-	).Return(nil).Once()
+	).Return(false, nil).Once()
 	m.On(
 		"Method2",
 		mock.MatchedBy(123), // want `the argument to mock.MatchedBy must be func\(int\) bool`
 		mock.Anything,
 		mock.Anything,
-	).Return(nil).Once()
+	).Return(false, nil).Once()
+}
+
+type conflictingReader interface {
+	Read() string
+}
+
+func TestMockMatchedBy_Assignability(t *testing.T) {
+	m := &MyMock{}
+
+	// testify calls the callback if the argument is assignable to its parameter.
+	m.On("Method1", mock.MatchedBy(func(a any) bool { return false })).Return(nil).Once()
+	m.On("Method4", mock.MatchedBy(func(r io.Reader) bool { return false })).Once()
+	m.On("Method4", mock.MatchedBy(func(r any) bool { return false })).Once()
+
+	// These only match some implementations of io.Reader.
+	m.On("Method4", mock.MatchedBy(func(r *strings.Reader) bool { return false })).Once()      // want `mock.MatchedBy callback takes \*strings.Reader, so it won't match every io.Reader$`
+	m.On("Method4", mock.MatchedBy(func(w io.Writer) bool { return false })).Once()            // want `mock.MatchedBy callback takes io.Writer, so it won't match every io.Reader$`
+	m.On("Method4", mock.MatchedBy(func(r internal.ValueReader) bool { return false })).Once() // want `mock.MatchedBy callback takes example.com/internal.ValueReader, so it won't match every io.Reader; it also panics if the argument is nil`
+
+	// These never match.
+	m.On("Method4", mock.MatchedBy(func(s internal.SomeType) bool { return false })).Once()     // want `the argument to mock.MatchedBy must be func\(io.Reader\) bool`
+	m.On("Method4", mock.MatchedBy(func(r conflictingReader) bool { return false })).Once()     // want `the argument to mock.MatchedBy must be func\(io.Reader\) bool`
+	m.On("Method1", mock.MatchedBy(func(r io.Reader) bool { return false })).Return(nil).Once() // want `the argument to mock.MatchedBy must be func\(string\) bool`
+
+	type myBool bool
+	m.On("Method1", mock.MatchedBy(func(s string) myBool { return false })).Return(nil).Once()
+}
+
+func TestMockMatchedBy_Variadic(t *testing.T) {
+	m := &MyMock{}
+
+	// The variadic arguments are matched as a single slice.
+	m.On("Method3", 1, internal.SomeType{}, mock.MatchedBy(func(c []bool) bool { return false })).Once()
+	m.On("Method3", 1, internal.SomeType{}, mock.MatchedBy(func(c bool) bool { return false })).Once() // want `the argument to mock.MatchedBy must be func\(\[\]bool\) bool \(hint: last parameter is variadic, so the matcher is called with all of the variadic arguments as a slice\)`
+	m.On("Method3", 1, internal.SomeType{}, mock.MatchedBy(func(c int) bool { return false })).Once()  // want `the argument to mock.MatchedBy must be func\(\[\]bool\) bool$`
 }
 
 func TestMockArgs_Any(t *testing.T) {
@@ -113,12 +149,12 @@ func TestMethodThatDoesExist_WrongArgumentTypes(t *testing.T) {
 		"string", // want `invalid parameter type in mock setup; string is not assignable to int`
 		true,
 		123, // want `invalid parameter type in mock setup; int is not assignable to string`
-	).Return(nil).Once()
+	).Return(false, nil).Once()
 	m.On("Method3",
 		1,
 		true, // want `invalid parameter type in mock setup; bool is not assignable to example.com/internal.SomeType`
 		[]bool{false},
-	).Return(nil).Once()
+	).Return().Once()
 }
 
 func TestMethodWithInterfaceParam_DifferentImplementations(t *testing.T) {
@@ -144,5 +180,5 @@ func TestMethodThatDoesExist_WrongArgumentTypes_Variadic(t *testing.T) {
 		1,
 		internal.SomeType{},
 		false, // want `invalid parameter type in mock setup; bool is not assignable to \[\]bool \(hint: last parameter is variadic, make it a slice\)`
-	).Return(nil).Once()
+	).Return().Once()
 }
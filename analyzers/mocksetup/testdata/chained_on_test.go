@@ -0,0 +1,42 @@
+package testdata
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+)
+
+func TestChainedOn(t *testing.T) {
+	m := &MyMock{}
+	m.On("Method1", "a").Return(nil).
+		On("Method2", 1, true, "b").Return(true, nil).Once().
+		On("Method4", mock.Anything)
+
+	m.On("Method1", "a").Return(nil).
+		On("Method5") // want `"Method5" is not a method of \*example.com.MyMock`
+	m.On("Method1", "a").Return(nil).
+		On("Method1", 1).Return(nil) // want "invalid parameter type in mock setup; int is not assignable to string"
+	m.On("Method1", "a").Return(nil).
+		On("Method2", 1, true) // want `call is mocked for 2 arguments, but method "Method2" takes 3`
+	m.On("Method1", "a").Return(nil).
+		On("Method2", 1, true, "b"). // want `method "Method2" has return values, but the mock setup has no call to Return`
+		On("Method1", "c").
+		Return(1) // want "invalid return type in mock setup; int is not assignable to error"
+
+	// Chained setups through a mockery expecter are on the same mock.
+	r := &MockRepo{}
+	r.EXPECT().Get(context.Background(), "id").Return(1, nil).
+		On("Delete", []string{"a"}).
+		On("Get", 1, 2).Return(1, nil) // want "invalid parameter type in mock setup; int is not assignable to context.Context" "invalid parameter type in mock setup; int is not assignable to string"
+
+	// We can't know where a chain stored in a variable came from.
+	c := m.On("Method1", "a").Return(nil)
+	c.On("Method5")
+}
+
+func TestCallParent(t *testing.T) {
+	m := &MyMock{}
+	m.On("Method1", "a").Return(nil).Parent.On("Method1", 1).Return(nil) // want "invalid parameter type in mock setup; int is not assignable to string"
+	m.On("Method1", "a").Return(nil).Parent.AssertCalled(t, "Methd1")    // want `"Methd1" is not a method of \*example.com.MyMock; did you mean "Method1"\?`
+}
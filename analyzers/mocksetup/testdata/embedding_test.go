@@ -0,0 +1,60 @@
+package testdata
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type BaseMock struct {
+	mock.Mock
+}
+
+type EmbedsBaseMock struct {
+	BaseMock
+}
+
+func (m *EmbedsBaseMock) Get(id string) error { return nil }
+
+type EmbedsMockPointer struct {
+	*mock.Mock
+}
+
+func (m *EmbedsMockPointer) Get(id string) error { return nil }
+
+type testifyMock = mock.Mock
+
+type EmbedsAliasedMock struct {
+	testifyMock
+}
+
+func (m *EmbedsAliasedMock) Get(id string) error { return nil }
+
+type AliasedMock = EmbedsBaseMock
+
+func TestEmbeddedThroughBaseMock(t *testing.T) {
+	m := &EmbedsBaseMock{}
+	m.On("Get", "id").Return(nil)
+	m.On("Get", 1).Return(nil) // want "invalid parameter type in mock setup; int is not assignable to string"
+	m.On("Put", "id")          // want `"Put" is not a method of \*example.com.EmbedsBaseMock`
+	m.On("Called")             // want `"Called" is not a method of \*example.com.EmbedsBaseMock`
+}
+
+func TestEmbeddedByPointer(t *testing.T) {
+	m := &EmbedsMockPointer{Mock: &mock.Mock{}}
+	m.On("Get", "id").Return(nil)
+	m.On("Get", 1).Return(nil) // want "invalid parameter type in mock setup; int is not assignable to string"
+	m.On("Get", "id")          // want `method "Get" has return values, but the mock setup has no call to Return`
+}
+
+func TestEmbeddedThroughAlias(t *testing.T) {
+	m := &EmbedsAliasedMock{}
+	m.On("Get", "id").Return(nil)
+	m.On("Get", 1).Return(nil) // want "invalid parameter type in mock setup; int is not assignable to string"
+}
+
+func TestAliasedMock(t *testing.T) {
+	m := &AliasedMock{}
+	m.On("Get", "id").Return(nil)
+	m.On("Get", "id").Return(1) // want "invalid return type in mock setup; int is not assignable to error"
+}
@@ -0,0 +1,92 @@
+package testdata
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+)
+
+func TestExpecter(t *testing.T) {
+	m := &MockRepo{}
+	m.EXPECT().Get(context.Background(), "id").Return(1, nil).Once()
+	m.EXPECT().Get(mock.Anything, mock.AnythingOfType("string")).Return(1, nil) // want "mock.AnythingOfType is equivalent to mock.Anything when the input type is concrete; use mock.Anything instead"
+	m.EXPECT().Get(mock.AnythingOfType("*context.emptyCtx"), "id").Return(1, nil)
+	m.EXPECT().Get(mock.Anything, mock.MatchedBy(func(s string) bool { return true })).Return(1, nil)
+	m.EXPECT().Get(mock.Anything, mock.MatchedBy(func(n int) bool { return true })).Return(1, nil) // want `the argument to mock.MatchedBy must be func\(string\) bool`
+	m.EXPECT().Get(
+		"ctx", // want "invalid parameter type in mock setup; string is not assignable to context.Context"
+		1,     // want "invalid parameter type in mock setup; int is not assignable to string"
+	).Return(1, nil)
+}
+
+func TestExpecter_Chain(t *testing.T) {
+	m := &MockRepo{}
+	m.EXPECT().Get(mock.Anything, "id").Once() // want `method "Get" has return values, but the mock setup has no call to Return`
+	m.EXPECT().Get(mock.Anything, "id").RunAndReturn(func(context.Context, string) (int, error) { return 0, nil }).Once()
+	m.EXPECT().Get(mock.Anything, "id").Run(func(ctx context.Context, id string) {}).Return(0, nil)
+	m.EXPECT().Get(mock.Anything, "id").Panic("oops")
+}
+
+func TestExpecter_Variadic(t *testing.T) {
+	m := &MockRepo{}
+	m.EXPECT().Delete()
+	m.EXPECT().Delete("a", mock.Anything, "c").Run(func(ids ...string) {})
+	m.EXPECT().Delete("a", 1) // want "invalid parameter type in mock setup; int is not assignable to string"
+	m.EXPECT().Delete([]any{1}...)
+	m.EXPECT().Delete().RunAndReturn(func(...string) {})
+}
+
+func TestExpecter_Stale(t *testing.T) {
+	m := &MockRepo{}
+	m.EXPECT().Stale("id", 1).Return("")                                   // want "invalid return type in mock setup; string is not assignable to error"
+	m.EXPECT().Stale("id", 1).Run(func(n int) {}).Return("")               // want `callback of type func\(n int\) doesn't match method "Stale"; it should be func\(id string, n int\)` "invalid return type in mock setup; string is not assignable to error"
+	m.EXPECT().Stale("id", 1).RunAndReturn(func(int) string { return "" }) // want `callback of type func\(int\) string doesn't match method "Stale"; it should be func\(id string, n int\) error`
+
+	// This method doesn't exist on the mock anymore, so there's nothing to check against.
+	m.EXPECT().Outdated(1)
+}
+
+// Methods can be added to the expecter through an alias of it.
+type MockRepoExpecter = MockRepo_Expecter
+
+type MockRepo_Count_Call struct {
+	*mock.Call
+}
+
+func (_m *MockRepo) Count(prefix string) int {
+	ret := _m.Called(prefix)
+	return ret.Int(0)
+}
+
+func (_e *MockRepoExpecter) Count(prefix interface{}) *MockRepo_Count_Call {
+	return &MockRepo_Count_Call{Call: _e.mock.On("Count", prefix)}
+}
+
+func TestExpecter_Alias(t *testing.T) {
+	m := &MockRepo{}
+	m.EXPECT().Count("a").Return(1)
+	m.EXPECT().Count(1).Return(1) // want "invalid parameter type in mock setup; int is not assignable to string"
+}
+
+// NotARepo is named like the mock of an expecter, but it isn't a mock.
+type NotARepo struct{}
+
+func (*NotARepo) Get(id string) {}
+
+type NotARepo_Expecter struct {
+	mock *mock.Mock
+}
+
+type NotARepo_Get_Call struct {
+	*mock.Call
+}
+
+func (_e *NotARepo_Expecter) Get(id interface{}) *NotARepo_Get_Call {
+	return &NotARepo_Get_Call{Call: _e.mock.On("Get", id)}
+}
+
+func TestExpecter_NotAMock(t *testing.T) {
+	e := &NotARepo_Expecter{mock: &mock.Mock{}}
+	e.Get(1).Return(1)
+}
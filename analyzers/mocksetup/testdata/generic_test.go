@@ -0,0 +1,54 @@
+package testdata
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type GenericMock[T any] struct {
+	mock.Mock
+}
+
+func (m *GenericMock[T]) Get(id string) (T, error) {
+	args := m.Called(id)
+	return args.Get(0).(T), args.Error(1)
+}
+
+func (m *GenericMock[T]) Put(id string, val T) {
+	m.Called(id, val)
+}
+
+func TestGenericMock(t *testing.T) {
+	ints := &GenericMock[int]{}
+	ints.On("Get", "id").Return(1, nil)
+	ints.On("Get", 1).Return(1, nil)      // want "invalid parameter type in mock setup; int is not assignable to string"
+	ints.On("Get", "id").Return("a", nil) // want "invalid return type in mock setup; string is not assignable to int"
+	ints.On("Put", "id", 1)
+	ints.On("Put", "id", "a") // want "invalid parameter type in mock setup; string is not assignable to int"
+	ints.On("Get", "id")      // want `method "Get" has return values, but the mock setup has no call to Return`
+
+	strs := &GenericMock[string]{}
+	strs.On("Get", "id").Return("a", nil)
+	strs.On("Get", "id").Return(1, nil) // want "invalid return type in mock setup; int is not assignable to string"
+	strs.On("Put", "id", "a")
+	strs.On("Put", "id", 1) // want "invalid parameter type in mock setup; int is not assignable to string"
+	strs.On("Put", "id", mock.Anything)
+	strs.AssertCalled(t, "Put", "id", 1) // want "invalid parameter type in call to AssertCalled; int is not assignable to string"
+}
+
+func TestGenericExpecter(t *testing.T) {
+	m := &MockStore[string, int]{}
+	m.EXPECT().Load("a").Return(1, nil)
+	m.EXPECT().Load(1).Return(1, nil) // want "invalid parameter type in mock setup; int is not assignable to string"
+	m.EXPECT().Load("a").Run(func(key string) {}).Return(1, nil)
+	m.EXPECT().Load("a")               // want `method "Load" has return values, but the mock setup has no call to Return`
+	m.On("Load", "a").Return("b", nil) // want "invalid return type in mock setup; string is not assignable to int"
+
+	other := &MockStore[int, []string]{}
+	other.EXPECT().Load(1).Return([]string{"a"}, nil)
+	other.EXPECT().Load("a").Return(nil, nil) // want "invalid parameter type in mock setup; string is not assignable to int"
+	other.On("Load", 1).Return([]string{}, nil).Run(func(args mock.Arguments) {
+		_ = args.Get(0).(string) // want `type assertion to string doesn't match parameter 0 of method "Load", which is int`
+	})
+}
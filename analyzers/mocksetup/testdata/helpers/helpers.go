@@ -0,0 +1,21 @@
+package helpers
+
+import (
+	"example.com"
+	"github.com/stretchr/testify/mock"
+)
+
+// ExpectMethod1 sets up Method1 with the given argument.
+func ExpectMethod1(m *testdata.MyMock, a any) *mock.Call { // want ExpectMethod1:`setupHelper\(On\("Method1"\)\)`
+	return m.On("Method1", a)
+}
+
+// Expect sets up any of the mock's methods.
+func Expect(m *testdata.MyMock, method string, args ...any) *mock.Call { // want Expect:`setupHelper\(On\(param 1\)\)`
+	return m.On(method, args...)
+}
+
+// AssertCalledWith asserts that the method was called with the given first argument.
+func AssertCalledWith(t mock.TestingT, m *testdata.MyMock, method string, arg any) { // want AssertCalledWith:`setupHelper\(AssertCalled\(param 2\)\)`
+	m.AssertCalled(t, method, arg)
+}
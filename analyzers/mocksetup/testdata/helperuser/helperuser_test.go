@@ -0,0 +1,36 @@
+package helperuser
+
+import (
+	"testing"
+
+	"example.com"
+	"example.com/helpers"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestHelpersFromAnotherPackage(t *testing.T) {
+	m := &testdata.MyMock{}
+
+	helpers.ExpectMethod1(m, "a").Return(nil)
+	helpers.ExpectMethod1(m, mock.Anything).Return(nil)
+	helpers.ExpectMethod1(m, 1).Return(nil) // want "invalid parameter type in mock setup; int is not assignable to string"
+
+	helpers.Expect(m, "Method1", "a").Return(nil)
+	helpers.Expect(m, "Method2", 1, true, "b").Return(false, nil)
+	helpers.Expect(m, "Method5")              // want `"Method5" is not a method of \*example.com.MyMock`
+	helpers.Expect(m, "Method1")              // want `call is mocked for 0 arguments, but method "Method1" takes 1`
+	helpers.Expect(m, "Method2", 1, "b", "c") // want "invalid parameter type in mock setup; string is not assignable to bool"
+
+	helpers.AssertCalledWith(t, m, "Method1", "a")
+	helpers.AssertCalledWith(t, m, "Method4", "a") // want "invalid parameter type in call to AssertCalled; string is not assignable to io.Reader"
+	helpers.AssertCalledWith(t, m, "Methd4", nil)  // want `"Methd4" is not a method of \*example.com.MyMock; did you mean "Method4"\?`
+
+	args := []any{1, true, "b"}
+	helpers.Expect(m, "Method2", args...).Return(false, nil)
+}
+
+func TestNonRecordingMethodsFromAnotherPackage(t *testing.T) {
+	m := &testdata.StubMock{}
+	m.On("Get", "id").Return("a", nil)
+	m.On("Ping").Return(nil) // want `method "Ping" never records its calls with Called, so this mock setup will never be matched`
+}
@@ -1,3 +1,11 @@
 package internal
 
 type SomeType struct{}
+
+type Reader struct{}
+
+func (r *Reader) Read(p []byte) (int, error) { return 0, nil }
+
+type ValueReader struct{}
+
+func (r ValueReader) Read(p []byte) (int, error) { return 0, nil }
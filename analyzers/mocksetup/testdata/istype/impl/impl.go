@@ -0,0 +1,15 @@
+package impl
+
+type Reader struct{}
+
+func (r *Reader) Read(p []byte) (int, error) { return 0, nil }
+
+type ValueReader struct{}
+
+func (r ValueReader) Read(p []byte) (int, error) { return 0, nil }
+
+type unexportedReader struct{}
+
+func (r *unexportedReader) Read(p []byte) (int, error) { return 0, nil }
+
+func NewUnexported() *unexportedReader { return &unexportedReader{} }
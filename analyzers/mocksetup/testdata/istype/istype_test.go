@@ -0,0 +1,45 @@
+package istype
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+)
+
+func TestIsType(t *testing.T) {
+	m := &MyMock{}
+
+	m.On("Read", mock.AnythingOfType("*strings.Reader"))     // want `mock.AnythingOfType can be replaced with the type-safe mock.IsType\(\(\*strings.Reader\)\(nil\)\)`
+	m.On("Read", mock.AnythingOfType("*istype.localReader")) // want `mock.AnythingOfType can be replaced with the type-safe mock.IsType\(\(\*localReader\)\(nil\)\)`
+
+	// impl isn't imported in this file, so it needs to be.
+	m.On("Read", mock.AnythingOfType("*impl.Reader"))     // want `mock.AnythingOfType can be replaced with the type-safe mock.IsType\(\(\*impl.Reader\)\(nil\)\)`
+	m.On("Read", mock.AnythingOfType("impl.ValueReader")) // want `mock.AnythingOfType can be replaced with the type-safe mock.IsType\(impl.ValueReader\{\}\)`
+
+	// Unexported types can't be referred to from here.
+	m.On("Read", mock.AnythingOfType("*impl.unexportedReader"))
+
+	m.Read(strings.NewReader(""))
+}
+
+func TestIsType_Conflict(t *testing.T) {
+	m := &MyMock{}
+
+	// Importing impl would conflict with this variable.
+	impl := 1
+	_ = impl
+
+	m.On("Read", mock.AnythingOfType("*impl.Reader"))
+}
+
+func TestIsType_Shadowed(t *testing.T) {
+	m := &MyMock{}
+
+	// These refer to variables here, not to the package and the type.
+	strings, localReader := 1, 2
+	_, _ = strings, localReader
+
+	m.On("Read", mock.AnythingOfType("*strings.Reader"))
+	m.On("Read", mock.AnythingOfType("*istype.localReader"))
+}
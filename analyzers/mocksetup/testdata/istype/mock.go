@@ -0,0 +1,20 @@
+package istype
+
+import (
+	"io"
+
+	"example.com/istype/impl"
+	"github.com/stretchr/testify/mock"
+)
+
+var _ = impl.NewUnexported
+
+type MyMock struct {
+	mock.Mock
+}
+
+func (m *MyMock) Read(r io.Reader) {}
+
+type localReader struct{}
+
+func (r *localReader) Read(p []byte) (int, error) { return 0, nil }
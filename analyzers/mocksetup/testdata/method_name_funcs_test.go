@@ -0,0 +1,44 @@
+package testdata
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAssertCalled(t *testing.T) {
+	m := &MyMock{}
+	m.AssertCalled(t, "Method1", "")
+	m.AssertCalled(t, "Method1", mock.Anything)
+	m.AssertCalled(t, "Method11", "")           // want `"Method11" is not a method of \*example.com.MyMock`
+	m.AssertCalled(t, randomString(), "")       // want "the name of a mocked method should be a constant"
+	m.AssertCalled(t, "Method1")                // want `call is asserted for 0 arguments, but method "Method1" takes 1`
+	m.AssertCalled(t, "Method1", 1)             // want "invalid parameter type in call to AssertCalled; int is not assignable to string"
+	m.AssertCalled(t, "Method3", 1, nil, false) // want `invalid parameter type in call to AssertCalled; untyped nil is not assignable to example.com/internal.SomeType` `invalid parameter type in call to AssertCalled; bool is not assignable to \[\]bool \(hint: last parameter is variadic, make it a slice\)`
+}
+
+func TestAssertNotCalled(t *testing.T) {
+	m := &MyMock{}
+	m.AssertNotCalled(t, "Method1", "")
+	m.AssertNotCalled(t, "Method11", "")   // want `"Method11" is not a method of \*example.com.MyMock`
+	m.AssertNotCalled(t, "Method1", "", 1) // want `call is asserted for 2 arguments, but method "Method1" takes 1`
+	m.AssertNotCalled(t, "Method1", true)  // want "invalid parameter type in call to AssertNotCalled; bool is not assignable to string"
+}
+
+func TestAssertNumberOfCalls(t *testing.T) {
+	m := &MyMock{}
+	m.AssertNumberOfCalls(t, "Method1", 1)
+	m.AssertNumberOfCalls(t, "Method11", 1)     // want `"Method11" is not a method of \*example.com.MyMock`
+	m.AssertNumberOfCalls(t, randomString(), 1) // want "the name of a mocked method should be a constant"
+}
+
+func TestMethodCalled(t *testing.T) {
+	m := &MyMock{}
+	m.MethodCalled("Method1", "")
+	m.MethodCalled("Method11", "") // want `"Method11" is not a method of \*example.com.MyMock`
+	m.MethodCalled("Method1")      // want `call is recorded for 0 arguments, but method "Method1" takes 1`
+	m.MethodCalled("Method1", 1)   // want "invalid parameter type in call to MethodCalled; int is not assignable to string"
+
+	// We don't know what's mocked when using the mock directly.
+	m.Mock.AssertCalled(t, "Method11")
+}
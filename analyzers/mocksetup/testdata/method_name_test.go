@@ -39,3 +39,12 @@ func TestTrickyConstantMethodNameThatDoesExist(t *testing.T) {
 	m := &MyMock{}
 	m.On(name3+name4, "").Return(nil).Once()
 }
+
+func TestMethodThatDoesNotExist_Suggestions(t *testing.T) {
+	m := &MyMock{}
+	m.On("Methd1", "").Return(nil)         // want `"Methd1" is not a method of \*example.com.MyMock; did you mean "Method1"\?`
+	m.On("method1", "").Return(nil)        // want `"method1" is not a method of \*example.com.MyMock; did you mean "Method1"\?`
+	m.On("Method5")                        // want `"Method5" is not a method of \*example.com.MyMock; did you mean "Method1", "Method2" or "Method3"\?`
+	m.On("SomethingElse")                  // want `"SomethingElse" is not a method of \*example.com.MyMock$`
+	m.AssertNumberOfCalls(t, "Mehtod4", 1) // want `"Mehtod4" is not a method of \*example.com.MyMock; did you mean "Method4"\?`
+}
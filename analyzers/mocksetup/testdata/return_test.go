@@ -0,0 +1,83 @@
+package testdata
+
+import (
+	"errors"
+	"testing"
+
+	"example.com/internal"
+	"github.com/stretchr/testify/mock"
+)
+
+type ReturnMock struct {
+	mock.Mock
+}
+
+func (m *ReturnMock) NoResults(a string)                             {}
+func (m *ReturnMock) OneResult(a string) int64                       { return 0 }
+func (m *ReturnMock) TwoResults(a string) (internal.SomeType, error) { return internal.SomeType{}, nil }
+
+func TestReturn_Missing(t *testing.T) {
+	m := &ReturnMock{}
+	m.On("NoResults", "")
+	m.On("NoResults", "").Once()
+	m.On("OneResult", "")                 // want `method "OneResult" has return values, but the mock setup has no call to Return`
+	m.On("TwoResults", "").Once().Maybe() // want `method "TwoResults" has return values, but the mock setup has no call to Return`
+
+	// Setups that don't return at all don't need return values.
+	m.On("OneResult", "").Panic("oh no")
+	m.On("OneResult", "").Unset()
+
+	// The next setup in a chain is checked separately.
+	m.On("OneResult", "").On("OneResult", "").Return(int64(1)) // want `method "OneResult" has return values, but the mock setup has no call to Return`
+
+	// If the call escapes, Return may be called later.
+	c := m.On("OneResult", "")
+	c.Return(int64(1))
+	setupReturn(m.On("TwoResults", ""))
+}
+
+func setupReturn(c *mock.Call) {
+	c.Return(internal.SomeType{}, nil)
+}
+
+func TestReturn_WrongNumber(t *testing.T) {
+	m := &ReturnMock{}
+	m.On("NoResults", "").Return()
+	m.On("NoResults", "").Return(nil)                 // want `call is mocked to return 1 values, but method "NoResults" returns 0`
+	m.On("OneResult", "").Return()                    // want `call is mocked to return 0 values, but method "OneResult" returns 1`
+	m.On("TwoResults", "").Once().Return(nil).Maybe() // want `call is mocked to return 1 values, but method "TwoResults" returns 2`
+
+	// We can't know how many values are spread.
+	m.On("TwoResults", "").Return([]any{nil}...)
+}
+
+func TestReturn_WrongTypes(t *testing.T) {
+	m := &ReturnMock{}
+	m.On("OneResult", "").Return(int64(1))
+	m.On("OneResult", "").Return(1)     // want `invalid return type in mock setup; int is not assignable to int64`
+	m.On("OneResult", "").Return("one") // want `invalid return type in mock setup; string is not assignable to int64`
+	m.On("OneResult", "").Return(any(nil))
+	m.On("OneResult", "").Return(mock.Anything) // want `mock.Anything is an argument matcher and can't be used as a return value`
+
+	m.On("TwoResults", "").Return(internal.SomeType{}, errors.New("oops"))
+	m.On("TwoResults", "").Return(&internal.SomeType{}, 1) // want `invalid return type in mock setup; \*example.com/internal.SomeType is not assignable to example.com/internal.SomeType` `invalid return type in mock setup; int is not assignable to error`
+}
+
+func TestReturn_Funcs(t *testing.T) {
+	m := &ReturnMock{}
+	m.On("OneResult", "").Return(func(a string) int64 { return 1 })
+	m.On("OneResult", "").Return(func(a int) int64 { return 1 }) // want `invalid return type in mock setup; func\(a int\) int64 is not assignable to int64`
+
+	m.On("TwoResults", "").Return(
+		func(string) internal.SomeType { return internal.SomeType{} },
+		func(string) error { return nil },
+	)
+	m.On("TwoResults", "").Return(
+		func(string) (internal.SomeType, error) { return internal.SomeType{}, nil },
+		nil,
+	)
+	m.On("TwoResults", "").Return(
+		internal.SomeType{},
+		func(string) (internal.SomeType, error) { return internal.SomeType{}, nil }, // want `invalid return type in mock setup; func\(string\) \(example.com/internal.SomeType, error\) is not assignable to error`
+	)
+}
@@ -0,0 +1,71 @@
+package testdata
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"testing"
+
+	"example.com/internal"
+	"github.com/stretchr/testify/mock"
+)
+
+type RunMock struct {
+	mock.Mock
+}
+
+func (m *RunMock) Method(s string, n int, b bool, err error, r io.Reader, opts ...internal.SomeType) {
+	m.Called(s, n, b, err, r, opts)
+}
+
+func TestRun(t *testing.T) {
+	m := &RunMock{}
+	m.On("Method", "", 0, false, nil, nil, []internal.SomeType(nil)).Run(func(args mock.Arguments) {
+		_ = args.String(0)
+		_ = args.Int(1)
+		_ = args.Bool(2)
+		_ = args.Error(3)
+		_ = args.Get(4).(io.Reader)
+		_ = args.Get(4).(*os.File)
+		_ = args.Get(4).(io.ReadWriter)
+		_ = args.Get(5).([]internal.SomeType)
+	}).Once()
+}
+
+func TestRun_OutOfRange(t *testing.T) {
+	m := &RunMock{}
+	m.On("Method", "", 0, false, nil, nil, []internal.SomeType(nil)).Run(func(args mock.Arguments) {
+		_ = args.Get(6)  // want `Get\(6\) is out of range; method "Method" has 6 parameters`
+		_ = args.Bool(8) // want `Bool\(8\) is out of range; method "Method" has 6 parameters`
+	})
+}
+
+func TestRun_WrongTypes(t *testing.T) {
+	m := &RunMock{}
+	m.On("Method", "", 0, false, nil, nil, []internal.SomeType(nil)).Run(func(args mock.Arguments) {
+		_ = args.Int(0)          // want `Int\(0\) requires parameter 0 of method "Method" to be int, but it is string`
+		_ = args.String(1)       // want `String\(1\) requires parameter 1 of method "Method" to be string, but it is int`
+		_ = args.Error(2)        // want `Error\(2\) requires parameter 2 of method "Method" to be error, but it is bool`
+		_ = args.Get(3).(string) // want `type assertion to string doesn't match parameter 3 of method "Method", which is error`
+		_ = args.Get(4).(*bytes.Buffer)
+		_ = args.Get(4).(*internal.SomeType) // want `type assertion to \*example.com/internal.SomeType doesn't match parameter 4 of method "Method", which is io.Reader`
+		_ = args.Get(5).(internal.SomeType)  // want `type assertion to example.com/internal.SomeType doesn't match parameter 5 of method "Method", which is \[\]example.com/internal.SomeType`
+	}).Return()
+}
+
+func TestRun_NotALiteral(t *testing.T) {
+	fn := func(args mock.Arguments) {
+		_ = args.Int(0)
+	}
+
+	m := &RunMock{}
+	m.On("Method", "", 0, false, nil, nil, []internal.SomeType(nil)).Run(fn)
+}
+
+func TestRun_OtherArguments(t *testing.T) {
+	m := &RunMock{}
+	m.On("Method", "", 0, false, nil, nil, []internal.SomeType(nil)).Run(func(args mock.Arguments) {
+		other := mock.Arguments{1}
+		_ = other.Int(0)
+	})
+}
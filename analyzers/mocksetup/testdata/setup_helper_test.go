@@ -0,0 +1,42 @@
+package testdata
+
+import (
+	"testing"
+
+	"example.com/internal"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestSetupHelpers(t *testing.T) {
+	m := &MyMock{}
+
+	// Helpers can be used before they're declared.
+	expectMethod2(m, 1, "b").Return(false, nil)
+	expectMethod2(m, 1, mock.Anything).Return(false, nil)
+	expectMethod2(m, 1, 2).Return(false, nil) // want "invalid parameter type in mock setup; int is not assignable to string"
+
+	expectNamed(m, "Method4", nil)
+	expectNamed(m, "Method1", "a")
+	expectNamed(m, "Method1", 1)   // want "invalid parameter type in mock setup; int is not assignable to string"
+	expectNamed(m, "Method3", "a") // want `call is mocked for 1 arguments, but method "Method3" takes 3`
+	expectNamed(m, "method1", "a") // want `"method1" is not a method of \*example.com.MyMock; did you mean "Method1"\?`
+
+	name := "Method1"
+	expectNamed(m, name, "a") // want "the name of a mocked method should be a constant"
+
+	expectMethod3(m, 1, internal.SomeType{}, []bool{true, false})
+	expectMethod3(m, 1, internal.SomeType{}, true, false) // want `variadic parameter "c" of method "Method3" is mocked for 2 separate arguments \(hint: last parameter is variadic, make it a slice\)`
+	expectMethod3(m, "a", internal.SomeType{}, []bool{})  // want "invalid parameter type in mock setup; string is not assignable to int"
+}
+
+func expectMethod2(m *MyMock, b int, d any) *mock.Call { // want expectMethod2:`setupHelper\(On\("Method2"\)\)`
+	return m.On("Method2", b, true, d)
+}
+
+func expectNamed(m *MyMock, name string, arg any) { // want expectNamed:`setupHelper\(On\(param 1\)\)`
+	m.On(name, arg)
+}
+
+func expectMethod3(m *MyMock, args ...any) { // want expectMethod3:`setupHelper\(On\("Method3"\)\)`
+	m.On("Method3", args...)
+}
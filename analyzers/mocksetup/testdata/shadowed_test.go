@@ -0,0 +1,39 @@
+package testdata
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// ShadowingMock mocks an interface whose methods have the same names as some of mock.Mock's.
+type ShadowingMock struct {
+	mock.Mock
+}
+
+func (m *ShadowingMock) On(event string, handler func()) error {
+	return m.Mock.Called(event, handler).Error(0)
+}
+
+func (m *ShadowingMock) Test(name string) bool {
+	return m.Mock.Called(name).Bool(0)
+}
+
+func (m *ShadowingMock) Get(id string) error {
+	return m.Mock.Called(id).Error(0)
+}
+
+func TestShadowedMethods(t *testing.T) {
+	m := &ShadowingMock{}
+	m.Mock.On("On", "event", mock.Anything).Return(nil)
+	m.Mock.On("On", 1, mock.Anything).Return(nil) // want "invalid parameter type in mock setup; int is not assignable to string"
+	m.Mock.On("On", "event")                      // want `call is mocked for 1 arguments, but method "On" takes 2`
+	m.Mock.On("Test", "name").Return(1)           // want "invalid return type in mock setup; int is not assignable to bool"
+	m.Mock.On("Get", "id").Return(nil)
+	m.Mock.On("Gte", "id").Return(nil)  // want `"Gte" is not a method of \*example.com.ShadowingMock`
+	m.AssertCalled(t, "On", "event", 1) // want `invalid parameter type in call to AssertCalled; int is not assignable to func\(\)`
+
+	// These call the mocked methods, not the ones that set up the mock.
+	_ = m.On("event", func() {})
+	_ = m.Test("name")
+}
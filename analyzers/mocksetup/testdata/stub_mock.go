@@ -0,0 +1,34 @@
+package testdata
+
+import "github.com/stretchr/testify/mock"
+
+// StubMock records the calls of some of its methods, but others are stubs.
+type StubMock struct {
+	mock.Mock
+}
+
+func (m *StubMock) Get(id string) (string, error) {
+	args := m.Called(id)
+	return args.String(0), args.Error(1)
+}
+
+func (m *StubMock) Delete(id string) error {
+	return m.delete(id)
+}
+
+func (m *StubMock) delete(id string) error {
+	return m.MethodCalled("Delete", id).Error(0)
+}
+
+func (m *StubMock) Ping() error { return nil } // want Ping:"nonRecording"
+
+func (m *StubMock) Name() string { // want Name:"nonRecording"
+	return "stub"
+}
+
+// FakeMock never records calls, so it isn't set up with On.
+type FakeMock struct {
+	mock.Mock
+}
+
+func (m *FakeMock) Get(id string) (string, error) { return id, nil }
@@ -0,0 +1,18 @@
+package testdata
+
+import "testing"
+
+func TestNonRecordingMethods(t *testing.T) {
+	m := &StubMock{}
+	m.On("Get", "id").Return("a", nil)
+	m.On("Delete", "id").Return(nil)
+	m.On("Ping").Return(nil)                                  // want `method "Ping" never records its calls with Called, so this mock setup will never be matched`
+	m.On("Name").Return("a").Maybe()                          // want `method "Name" never records its calls with Called, so this mock setup will never be matched`
+	m.On("Get", "id").Return("a", nil).On("Ping").Return(nil) // want `method "Ping" never records its calls with Called, so this mock setup will never be matched`
+
+	// Asserting on the method is still fine, even if it won't be very useful.
+	m.AssertNotCalled(t, "Ping")
+
+	f := &FakeMock{}
+	f.On("Get", "id").Return("a", nil)
+}
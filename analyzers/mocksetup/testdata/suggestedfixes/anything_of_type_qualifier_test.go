@@ -0,0 +1,17 @@
+package suggestedfixes
+
+import (
+	strs "strings"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAnythingOfTypeQualifier(t *testing.T) {
+	m := &MyMock{}
+
+	// Types are qualified by their package name, not by their import path or the name they're
+	// imported as.
+	m.On("Method1", mock.AnythingOfType("*strs.Reader"), &strs.Builder{})      // want `type "\*strs.Reader" in mock.AnythingOfType will never match because types are qualified by package name; use "\*strings.Reader"`
+	m.On("Method1", mock.AnythingOfType("[]*strings.Reader"), &strs.Builder{}) // want `type "\[\]\*strings.Reader" in mock.AnythingOfType doesn't implement io.Reader, so it will never match`
+}
@@ -0,0 +1,40 @@
+package suggestedfixes
+
+import (
+	"context"
+	"testing"
+
+	tmock "github.com/stretchr/testify/mock"
+)
+
+type CountMock struct {
+	tmock.Mock
+}
+
+func (m *CountMock) NoArgs()                                 {}
+func (m *CountMock) ThreeArgs(ctx context.Context, a, b int) {}
+
+func TestArgCount_TooFew(t *testing.T) {
+	m := &CountMock{}
+	m.On("ThreeArgs")                                 // want `call is mocked for 0 arguments, but method "ThreeArgs" takes 3`
+	m.On("ThreeArgs", context.Background())           // want `call is mocked for 1 arguments, but method "ThreeArgs" takes 3`
+	m.AssertCalled(t, "ThreeArgs", tmock.Anything, 1) // want `call is asserted for 2 arguments, but method "ThreeArgs" takes 3`
+	m.On(                                             // want `call is mocked for 2 arguments, but method "ThreeArgs" takes 3`
+		"ThreeArgs",
+		tmock.Anything,
+		1,
+	)
+
+	// The arguments to MethodCalled aren't matchers.
+	m.MethodCalled("ThreeArgs") // want `call is recorded for 0 arguments, but method "ThreeArgs" takes 3`
+}
+
+func TestArgCount_TooMany(t *testing.T) {
+	m := &CountMock{}
+	m.On("NoArgs", tmock.Anything)                                          // want `call is mocked for 1 arguments, but method "NoArgs" takes 0`
+	m.On("ThreeArgs", tmock.Anything, 1, 2, tmock.Anything, tmock.Anything) // want `call is mocked for 5 arguments, but method "ThreeArgs" takes 3`
+	m.AssertNotCalled(t, "ThreeArgs", tmock.Anything, 1, 2, tmock.Anything) // want `call is asserted for 4 arguments, but method "ThreeArgs" takes 3`
+
+	// We don't know which of these should be removed.
+	m.On("ThreeArgs", tmock.Anything, 1, 2, 3) // want `call is mocked for 4 arguments, but method "ThreeArgs" takes 3`
+}
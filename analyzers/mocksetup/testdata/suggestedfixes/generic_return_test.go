@@ -0,0 +1,22 @@
+package suggestedfixes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type GenericMock[T any] struct {
+	mock.Mock
+}
+
+func (m *GenericMock[T]) Get() (T, error) { return *new(T), nil }
+
+func TestGenericMissingReturn(t *testing.T) {
+	// The zero values are for the type arguments rather than the type parameters.
+	ints := &GenericMock[int]{}
+	ints.On("Get") // want `method "Get" has return values, but the mock setup has no call to Return`
+
+	floats := &GenericMock[float64]{}
+	floats.On("Get") // want `method "Get" has return values, but the mock setup has no call to Return`
+}
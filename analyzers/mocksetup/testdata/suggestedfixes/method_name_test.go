@@ -0,0 +1,35 @@
+package suggestedfixes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type NamesMock struct {
+	mock.Mock
+}
+
+func (m *NamesMock) Get(id string)    {}
+func (m *NamesMock) GetAll()          {}
+func (m *NamesMock) Set(id string)    {}
+func (m *NamesMock) Delete(id string) {}
+
+const (
+	prefix = "Del"
+	suffix = "ete2"
+)
+
+func TestUnknownMethodName(t *testing.T) {
+	m := &NamesMock{}
+	m.On("Gett", "")           // want `"Gett" is not a method of \*example.com/suggestedfixes.NamesMock; did you mean "Get"\?`
+	m.On("delete", "")         // want `"delete" is not a method of \*example.com/suggestedfixes.NamesMock; did you mean "Delete"\?`
+	m.On(("GetAl"))            // want `"GetAl" is not a method of \*example.com/suggestedfixes.NamesMock; did you mean "GetAll"\?`
+	m.AssertCalled(t, "Dlete") // want `"Dlete" is not a method of \*example.com/suggestedfixes.NamesMock; did you mean "Delete"\?`
+
+	// There's no clear winner here.
+	m.On("Bet", "") // want `"Bet" is not a method of \*example.com/suggestedfixes.NamesMock; did you mean "Get" or "Set"\?`
+
+	// We can't rewrite a constant expression.
+	m.On(prefix+suffix, "") // want `"Delete2" is not a method of \*example.com/suggestedfixes.NamesMock; did you mean "Delete"\?`
+}
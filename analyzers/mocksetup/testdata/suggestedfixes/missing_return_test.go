@@ -0,0 +1,35 @@
+package suggestedfixes
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type ReturnMock struct {
+	mock.Mock
+}
+
+type myString string
+
+func (m *ReturnMock) Basics() (int, string, bool, float64, error) { return 0, "", false, 0, nil }
+func (m *ReturnMock) Named() (time.Duration, myString)            { return 0, "" }
+func (m *ReturnMock) Composite() (bytes.Buffer, *bytes.Buffer, []int, [2]int) {
+	return bytes.Buffer{}, nil, nil, [2]int{}
+}
+func (m *ReturnMock) Nillable() (*ReturnMock, map[string]int, <-chan int, func() error) {
+	return nil, nil, nil, nil
+}
+
+func TestMissingReturn(t *testing.T) {
+	m := &ReturnMock{}
+	m.On("Basics")             // want `method "Basics" has return values, but the mock setup has no call to Return`
+	m.On("Named").Once()       // want `method "Named" has return values, but the mock setup has no call to Return`
+	m.On("Composite").Times(2) // want `method "Composite" has return values, but the mock setup has no call to Return`
+	m.On("Nillable")           // want `method "Nillable" has return values, but the mock setup has no call to Return`
+
+	// strings isn't imported in this file, so we can't offer a fix.
+	m.On("Unimported").Maybe() // want `method "Unimported" has return values, but the mock setup has no call to Return`
+}
@@ -0,0 +1,5 @@
+package suggestedfixes
+
+import "strings"
+
+func (m *ReturnMock) Unimported() strings.Builder { return strings.Builder{} }
@@ -0,0 +1,36 @@
+package suggestedfixes
+
+import (
+	"testing"
+	"time"
+
+	tmock "github.com/stretchr/testify/mock"
+)
+
+type VariadicMock struct {
+	tmock.Mock
+}
+
+func (m *VariadicMock) Durations(id string, ds ...time.Duration) {}
+func (m *VariadicMock) Floats(fs ...float64)                     {}
+func (m *VariadicMock) Ptrs(ps ...*VariadicMock)                 {}
+func (m *VariadicMock) Unimported(id string, ts ...time.Month)   {}
+func (m *VariadicMock) Timers(id string, ts ...*time.Timer)      {}
+
+func TestVariadic_SingleArg(t *testing.T) {
+	m := &VariadicMock{}
+	m.On("Durations", "id", time.Second) // want `invalid parameter type in mock setup; time.Duration is not assignable to \[\]time.Duration \(hint: last parameter is variadic, make it a slice\)`
+	m.On("Floats", 1.5)                  // want `invalid parameter type in mock setup; float64 is not assignable to \[\]float64 \(hint: last parameter is variadic, make it a slice\)`
+	m.On("Ptrs", m)                      // want `invalid parameter type in mock setup; \*example.com/suggestedfixes.VariadicMock is not assignable to \[\]\*example.com/suggestedfixes.VariadicMock \(hint: last parameter is variadic, make it a slice\)`
+}
+
+func TestVariadic_Spread(t *testing.T) {
+	m := &VariadicMock{}
+	m.On("Durations", "id", time.Second, time.Minute) // want `variadic parameter "ds" of method "Durations" is mocked for 2 separate arguments \(hint: last parameter is variadic, make it a slice\)`
+	m.On("Floats", 1, 2.5, 3)                         // want `variadic parameter "fs" of method "Floats" is mocked for 3 separate arguments \(hint: last parameter is variadic, make it a slice\)`
+	m.AssertCalled(t, "Ptrs", m, nil)                 // want `variadic parameter "ps" of method "Ptrs" is asserted for 2 separate arguments \(hint: last parameter is variadic, make it a slice\)`
+	m.On("Timers", "id", nil, (*time.Timer)(nil))     // want `variadic parameter "ts" of method "Timers" is mocked for 2 separate arguments \(hint: last parameter is variadic, make it a slice\)`
+
+	// mock.Anything can't be put in a slice, so this is just the wrong number of arguments.
+	m.On("Durations", "id", time.Second, tmock.Anything) // want `call is mocked for 3 arguments, but method "Durations" takes 2`
+}
@@ -0,0 +1,88 @@
+package mocksetup
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// spreadVariadicArgs returns the arguments for a variadic method's last parameter if they were
+// passed individually rather than as a single slice, e.g. m.On("Method", "a", "b", "c") for
+// Method(a string, rest ...string). Mock setups must pass these as a slice because that's how
+// they're recorded by the mock. It returns nil if that's not the case.
+func spreadVariadicArgs(info *types.Info, sig *types.Signature, args []ast.Expr) []ast.Expr {
+	numParams := sig.Params().Len()
+	if !sig.Variadic() || len(args) <= numParams {
+		return nil
+	}
+
+	elem := sig.Params().At(numParams - 1).Type().(*types.Slice).Elem()
+	spread := args[numParams-1:]
+	for _, arg := range spread {
+		if arg == nil || !fitsElem(info, arg, elem) {
+			return nil
+		}
+	}
+
+	return spread
+}
+
+// fitsElem returns whether the argument could be an element of a slice of the given element type.
+// Untyped constants are converted to their default type when passed as an interface{}, so we
+// consider whether the constant could take on the element type in a slice literal.
+func fitsElem(info *types.Info, arg ast.Expr, elem types.Type) bool {
+	if isMockAnything(info, arg) {
+		// mock.Anything isn't matched when it's an element of a slice.
+		return false
+	}
+
+	tv, ok := info.Types[arg]
+	if !ok {
+		return false
+	}
+
+	basic, isBasic := elem.Underlying().(*types.Basic)
+	if tv.Value == nil || !isBasic {
+		return types.AssignableTo(tv.Type, elem)
+	}
+
+	switch tv.Value.Kind() {
+	case constant.Bool:
+		return basic.Info()&types.IsBoolean != 0
+	case constant.String:
+		return basic.Info()&types.IsString != 0
+	case constant.Int:
+		return basic.Info()&types.IsNumeric != 0
+	case constant.Float:
+		return basic.Info()&(types.IsFloat|types.IsComplex) != 0
+	case constant.Complex:
+		return basic.Info()&types.IsComplex != 0
+	default:
+		return false
+	}
+}
+
+// variadicSliceFixes returns a fix that wraps the arguments in a slice literal of the given slice
+// type, or nil if the type can't be expressed in the file.
+func variadicSliceFixes(pass *analysis.Pass, file *ast.File, sliceTyp types.Type, args []ast.Expr) []analysis.SuggestedFix {
+	ok := true
+	typStr := types.TypeString(sliceTyp, fileQualifier(pass, file, &ok))
+	if !ok {
+		return nil
+	}
+
+	return []analysis.SuggestedFix{{
+		Message: "wrap the variadic arguments in a " + typStr,
+		TextEdits: []analysis.TextEdit{{
+			Pos:     args[0].Pos(),
+			End:     args[0].Pos(),
+			NewText: []byte(typStr + "{"),
+		}, {
+			Pos:     args[len(args)-1].End(),
+			End:     args[len(args)-1].End(),
+			NewText: []byte("}"),
+		}},
+	}}
+}
@@ -0,0 +1,343 @@
+package mockstale
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/cszczepaniak/gomockcheck/analyzers/internal/typeutils"
+	"github.com/cszczepaniak/gomockcheck/names"
+	"golang.org/x/tools/go/analysis"
+)
+
+func New(typs ...names.QualifiedType) *analysis.Analyzer {
+	r := &runner{
+		types: slices.Concat([]names.QualifiedType{{
+			PkgPath: "github.com/stretchr/testify/mock",
+			Name:    "Mock",
+		}}, typs),
+	}
+
+	return &analysis.Analyzer{
+		Name: "mockstale",
+		Doc:  "Checks that mocks are up to date with the interfaces they mock",
+		Run:  r.run,
+	}
+}
+
+type runner struct {
+	types []names.QualifiedType
+}
+
+const implementsDirective = "//gomockcheck:implements "
+
+// mockeryDoc matches the doc comment that mockery generates for a mock type, e.g. "MockRepo is an
+// autogenerated mock type for the Repo type".
+var mockeryDoc = regexp.MustCompile(`is an autogenerated mock type for the (\w+) type`)
+
+func (r *runner) run(pass *analysis.Pass) (any, error) {
+	for _, file := range pass.Files {
+		for _, decl := range file.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.TYPE {
+				continue
+			}
+
+			for _, spec := range gen.Specs {
+				spec := spec.(*ast.TypeSpec)
+				doc := spec.Doc
+				if doc == nil && len(gen.Specs) == 1 {
+					doc = gen.Doc
+				}
+
+				r.checkMockType(pass, file, spec, doc)
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+func (r *runner) checkMockType(pass *analysis.Pass, file *ast.File, spec *ast.TypeSpec, doc *ast.CommentGroup) {
+	if doc == nil || spec.Assign.IsValid() {
+		return
+	}
+
+	obj, ok := pass.TypesInfo.Defs[spec.Name].(*types.TypeName)
+	if !ok || typeutils.EmbeddedMockType(obj.Type(), r.isMockObj) == nil {
+		return
+	}
+
+	iface := r.mockedInterface(pass, file, doc)
+	if iface == nil {
+		return
+	}
+
+	mockTyp, ifaceTyp, ok := instantiate(obj.Type().(*types.Named), iface)
+	if !ok {
+		return
+	}
+
+	ifaceName := qualifier(iface.Obj().Pkg()) + "." + iface.Obj().Name()
+	ifaceMethods := ifaceTyp.Underlying().(*types.Interface)
+
+	mockMethods := make(map[string]*types.Func)
+	for m := range typeutils.DistinctMethods(pass.Pkg, types.NewPointer(mockTyp), r.isMockObj) {
+		fn := m.Obj().(*types.Func)
+		if isExpecter(fn) {
+			continue
+		}
+		mockMethods[fn.Name()] = fn
+	}
+
+	var missing []string
+	for i := range ifaceMethods.NumMethods() {
+		want := ifaceMethods.Method(i)
+		got, ok := mockMethods[want.Name()]
+		if !ok {
+			missing = append(missing, want.Name())
+			continue
+		}
+		delete(mockMethods, want.Name())
+
+		if !types.Identical(got.Type(), want.Type()) {
+			pass.Reportf(
+				methodPos(pass.Pkg, spec, got),
+				"method %s of mock %s is %s, but %s has %s",
+				got.Name(),
+				obj.Name(),
+				signatureString(got),
+				ifaceName,
+				signatureString(want),
+			)
+		}
+	}
+
+	if len(missing) > 0 {
+		pass.Reportf(
+			spec.Name.Pos(),
+			"mock %s is missing methods of %s: %s",
+			obj.Name(),
+			ifaceName,
+			strings.Join(missing, ", "),
+		)
+	}
+
+	extra := make([]*types.Func, 0, len(mockMethods))
+	for _, fn := range mockMethods {
+		extra = append(extra, fn)
+	}
+	slices.SortFunc(extra, func(a, b *types.Func) int { return int(a.Pos() - b.Pos()) })
+	for _, fn := range extra {
+		if !fn.Exported() {
+			// Unexported methods that aren't part of the interface are helpers.
+			continue
+		}
+		pass.Reportf(
+			methodPos(pass.Pkg, spec, fn),
+			"method %s of mock %s isn't a method of %s",
+			fn.Name(),
+			obj.Name(),
+			ifaceName,
+		)
+	}
+}
+
+// mockedInterface returns the interface named by the doc comment of a mock type, either in a
+// //gomockcheck:implements directive or in the comment mockery generates. It returns nil if there
+// isn't one, or if it can't be found.
+func (r *runner) mockedInterface(pass *analysis.Pass, file *ast.File, doc *ast.CommentGroup) *types.Named {
+	for _, c := range doc.List {
+		name, ok := strings.CutPrefix(c.Text, implementsDirective)
+		if !ok {
+			continue
+		}
+
+		name, _, _ = strings.Cut(strings.TrimSpace(name), " ")
+		iface, err := lookupInterface(pass, file, name)
+		if err != nil {
+			pass.Reportf(c.Pos(), "can't check the mock against %s: %s", name, err)
+			return nil
+		}
+		return iface
+	}
+
+	// mockery doesn't say which package the interface is in, so look for it in the mock's package
+	// and then in the packages it depends on, nearest first. Those are the only packages whose types
+	// we know about.
+	m := mockeryDoc.FindStringSubmatch(doc.Text())
+	if m == nil {
+		return nil
+	}
+
+	seen := map[*types.Package]struct{}{pass.Pkg: {}}
+	for pkgs := []*types.Package{pass.Pkg}; len(pkgs) > 0; {
+		var found *types.Named
+		var next []*types.Package
+		for _, pkg := range pkgs {
+			if iface := interfaceIn(pkg, m[1]); iface != nil {
+				if found != nil {
+					// It's ambiguous, so we can't tell which one is mocked.
+					return nil
+				}
+				found = iface
+			}
+
+			for _, imp := range pkg.Imports() {
+				if _, ok := seen[imp]; !ok {
+					seen[imp] = struct{}{}
+					next = append(next, imp)
+				}
+			}
+		}
+
+		if found != nil {
+			return found
+		}
+		pkgs = next
+	}
+
+	return nil
+}
+
+// lookupInterface returns the interface with the given name, qualified by the path of its package
+// or the name the file imports it with. An unqualified name refers to the current package.
+func lookupInterface(pass *analysis.Pass, file *ast.File, name string) (*types.Named, error) {
+	pkgName, typeName := "", name
+	if i := strings.LastIndex(name, "."); i >= 0 {
+		pkgName, typeName = name[:i], name[i+1:]
+	}
+
+	pkg := findPackage(pass, file, pkgName)
+	if pkg == nil {
+		return nil, fmt.Errorf("package %s isn't imported", pkgName)
+	}
+
+	iface := interfaceIn(pkg, typeName)
+	if iface == nil {
+		return nil, fmt.Errorf("there is no interface %s in package %s", typeName, pkg.Path())
+	}
+
+	return iface, nil
+}
+
+// findPackage returns the package with the given path or name. Packages can be referred to by name
+// only if the file imports them; otherwise, any package that's imported (even indirectly) can be
+// referred to by path.
+func findPackage(pass *analysis.Pass, file *ast.File, name string) *types.Package {
+	if name == "" {
+		return pass.Pkg
+	}
+
+	for _, imp := range file.Imports {
+		obj := pass.TypesInfo.PkgNameOf(imp)
+		if obj != nil && (obj.Name() == name || obj.Imported().Path() == name) {
+			return obj.Imported()
+		}
+	}
+
+	seen := make(map[*types.Package]struct{})
+	var find func(pkgs []*types.Package) *types.Package
+	find = func(pkgs []*types.Package) *types.Package {
+		for _, pkg := range pkgs {
+			if _, ok := seen[pkg]; ok {
+				continue
+			}
+			seen[pkg] = struct{}{}
+
+			if pkg.Path() == name {
+				return pkg
+			}
+			if found := find(pkg.Imports()); found != nil {
+				return found
+			}
+		}
+		return nil
+	}
+
+	return find(pass.Pkg.Imports())
+}
+
+// interfaceIn returns the interface type with the given name in the package, or nil if there isn't
+// one.
+func interfaceIn(pkg *types.Package, name string) *types.Named {
+	obj, ok := pkg.Scope().Lookup(name).(*types.TypeName)
+	if !ok || obj.IsAlias() {
+		return nil
+	}
+
+	named, ok := obj.Type().(*types.Named)
+	if !ok || !types.IsInterface(named) {
+		return nil
+	}
+
+	return named
+}
+
+// instantiate returns the mock and interface types to compare. If they're generic, both are
+// instantiated with the mock's type parameters so that their methods can be compared. It returns
+// false if they can't be, e.g. because they have different numbers of type parameters.
+func instantiate(mock, iface *types.Named) (types.Type, types.Type, bool) {
+	if mock.TypeParams().Len() != iface.TypeParams().Len() {
+		return nil, nil, false
+	}
+
+	if mock.TypeParams().Len() == 0 {
+		return mock, iface, true
+	}
+
+	args := make([]types.Type, 0, mock.TypeParams().Len())
+	for i := range mock.TypeParams().Len() {
+		args = append(args, mock.TypeParams().At(i))
+	}
+
+	mockInst, err := types.Instantiate(nil, mock, args, true)
+	if err != nil {
+		return nil, nil, false
+	}
+
+	ifaceInst, err := types.Instantiate(nil, iface, args, true)
+	if err != nil {
+		return nil, nil, false
+	}
+
+	return mockInst, ifaceInst, true
+}
+
+// isExpecter returns whether the method is the EXPECT method that mockery generates, which returns
+// the mock's typed expecter.
+func isExpecter(fn *types.Func) bool {
+	sig := fn.Signature()
+	return fn.Name() == "EXPECT" && sig.Params().Len() == 0 && sig.Results().Len() == 1
+}
+
+// methodPos returns the position to report a problem with one of a mock's methods at. That's the
+// method's declaration if it's declared in this package (it could be promoted from another one),
+// or the mock type's otherwise.
+func methodPos(pkg *types.Package, spec *ast.TypeSpec, fn *types.Func) token.Pos {
+	if fn.Pkg() == pkg {
+		return fn.Origin().Pos()
+	}
+	return spec.Name.Pos()
+}
+
+// signatureString formats the signature of the method without its receiver, e.g.
+// func(id string) error.
+func signatureString(fn *types.Func) string {
+	sig := fn.Signature()
+	sig = types.NewSignatureType(nil, nil, nil, sig.Params(), sig.Results(), sig.Variadic())
+	return types.TypeString(sig, qualifier)
+}
+
+// qualifier qualifies types by the names of their packages, like they're written in Go code.
+func qualifier(pkg *types.Package) string {
+	return pkg.Name()
+}
+
+func (r *runner) isMockObj(obj types.Object) bool {
+	return names.IsOneOf(obj, r.types...)
+}
@@ -0,0 +1,11 @@
+package mockstale
+
+import (
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestMockStale(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), New(), "./...")
+}
@@ -0,0 +1,17 @@
+package inpackage
+
+import mock "github.com/stretchr/testify/mock"
+
+type Pinger interface {
+	Ping() error
+}
+
+// MockPinger is an autogenerated mock type for the Pinger type
+type MockPinger struct { // want `mock MockPinger is missing methods of inpackage.Pinger: Ping`
+	mock.Mock
+}
+
+// MockOther is an autogenerated mock type for the Other type
+type MockOther struct {
+	mock.Mock
+}
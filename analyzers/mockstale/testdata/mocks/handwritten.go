@@ -0,0 +1,54 @@
+package mocks
+
+import (
+	"context"
+
+	"example.com/store"
+	"github.com/stretchr/testify/mock"
+)
+
+// Repo is a hand-written mock.
+//
+//gomockcheck:implements store.Repo
+type Repo struct { // want `mock Repo is missing methods of store.Repo: Delete, List`
+	mock.Mock
+}
+
+func (m *Repo) Get(ctx context.Context, id string) (store.Item, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(store.Item), args.Error(1)
+}
+
+func (m *Repo) get(id string) store.Item {
+	return store.Item{ID: id}
+}
+
+// Closer is mocked by the path of the interface's package.
+//
+//gomockcheck:implements example.com/store.Closer
+type Closer struct {
+	mock.Mock
+}
+
+func (m *Closer) Close() error {
+	return m.Called().Error(0)
+}
+
+// Unknown refers to a package that isn't imported.
+//
+//gomockcheck:implements example.com/other.Closer // want `can't check the mock against example.com/other.Closer: package example.com/other isn't imported`
+type Unknown struct {
+	mock.Mock
+}
+
+// NotAnInterface refers to a type that isn't an interface.
+//
+//gomockcheck:implements store.Item // want `can't check the mock against store.Item: there is no interface Item in package example.com/store`
+type NotAnInterface struct {
+	mock.Mock
+}
+
+// NotAMock isn't a mock, so it isn't checked.
+//
+//gomockcheck:implements store.Closer
+type NotAMock struct{}
@@ -0,0 +1,7 @@
+package notify
+
+import "context"
+
+type Notifier interface {
+	Notify(ctx context.Context, id string) error
+}
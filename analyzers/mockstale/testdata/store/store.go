@@ -0,0 +1,30 @@
+package store
+
+import (
+	"context"
+
+	"example.com/notify"
+)
+
+type Item struct {
+	ID string
+}
+
+type Subscription struct {
+	Notifier notify.Notifier
+}
+
+type Repo interface {
+	Get(ctx context.Context, id string) (Item, error)
+	List(ctx context.Context) ([]Item, error)
+	Delete(ctx context.Context, id string) error
+}
+
+type Cache[K comparable, V any] interface {
+	Load(key K) (V, bool)
+	Store(key K, val V)
+}
+
+type Closer interface {
+	Close() error
+}
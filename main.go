@@ -2,7 +2,9 @@ package main
 
 import (
 	"github.com/cszczepaniak/gomockcheck/analyzers/assertexpectations"
+	"github.com/cszczepaniak/gomockcheck/analyzers/mockimpl"
 	"github.com/cszczepaniak/gomockcheck/analyzers/mocksetup"
+	"github.com/cszczepaniak/gomockcheck/analyzers/mockstale"
 	"golang.org/x/tools/go/analysis/multichecker"
 )
 
@@ -10,5 +12,7 @@ func main() {
 	multichecker.Main(
 		assertexpectations.New(),
 		mocksetup.New(),
+		mockimpl.New(),
+		mockstale.New(),
 	)
 }
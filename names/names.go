@@ -25,6 +25,10 @@ type QualifiedType struct {
 }
 
 func IsOneOf(obj types.Object, typs ...QualifiedType) bool {
+	if obj == nil || obj.Pkg() == nil {
+		return false
+	}
+
 	for _, t := range typs {
 		if obj.Pkg().Path() == t.PkgPath && obj.Name() == t.Name {
 			return true